@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// benchBaseline is the format of the file written by --bench-save and read by --bench-baseline
+//
+// It is keyed by "dayNN/parse" or "dayNN/partN", with named inputs
+// being keyed as "dayNN/input/parse" or "dayNN/input/partN"
+type benchBaseline map[string]benchBaselineEntry
+
+type benchBaselineEntry struct {
+	Runs        int    `json:"runs"`
+	MinNS       int64  `json:"min_ns"`
+	MedianNS    int64  `json:"median_ns"`
+	P95NS       int64  `json:"p95_ns"`
+	MaxNS       int64  `json:"max_ns"`
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+}
+
+// benchConfig is the configuration for running in benchmark mode
+type benchConfig struct {
+	opts         runner.BenchOptions
+	baselineFile string  // If set, compare against this baseline
+	saveFile     string  // If set, save the results to this file
+	threshold    float64 // How much slower (as a fraction) the median can be before flagging a regression
+}
+
+// runBenchmarks benchmarks each of the given days in turn, returning the exit code to use
+func runBenchmarks(ctx context.Context, days []runner.RunnableDay, cfg benchConfig) int {
+	var baseline benchBaseline
+	if cfg.baselineFile != "" {
+		data, err := os.ReadFile(cfg.baselineFile)
+		if err != nil {
+			log.Error().Err(err).Str("path", cfg.baselineFile).Msg("Failed to read benchmark baseline")
+			return 1
+		}
+
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			log.Error().Err(err).Str("path", cfg.baselineFile).Msg("Failed to parse benchmark baseline")
+			return 1
+		}
+	}
+
+	exitCode := 0
+	current := make(benchBaseline)
+	for _, day := range days {
+		if ctx.Err() != nil {
+			log.Warn().Err(ctx.Err()).Msg("Cancelled")
+			return 1
+		}
+
+		for _, result := range day.Bench(ctx, cfg.opts) {
+			if result.Err != nil {
+				exitCode = 1
+				continue
+			}
+
+			prefix := result.ID().String()
+			if result.Input != runner.DefaultInput {
+				prefix = fmt.Sprintf("%s/%s", prefix, result.Input)
+			}
+
+			record := func(stage string, stats runner.BenchStats) {
+				key := fmt.Sprintf("%s/%s", prefix, stage)
+				current[key] = newBaselineEntry(stats)
+
+				if previous, found := baseline[key]; found && compareToBaseline(key, previous, stats, cfg.threshold) {
+					exitCode = 1
+				}
+			}
+
+			record("parse", result.Parse)
+			for _, part := range result.Parts {
+				switch {
+				case part.NotImplemented:
+					// Nothing to record
+				case part.Err != nil:
+					exitCode = 1
+				default:
+					record(fmt.Sprintf("part%d", part.Part), part.Stats)
+				}
+			}
+		}
+	}
+
+	if cfg.saveFile != "" {
+		data, err := json.MarshalIndent(current, "", "  ")
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to encode benchmark baseline")
+			return 1
+		}
+
+		if err := os.WriteFile(cfg.saveFile, append(data, '\n'), 0644); err != nil {
+			log.Error().Err(err).Str("path", cfg.saveFile).Msg("Failed to write benchmark baseline")
+			return 1
+		}
+		log.Info().Str("path", cfg.saveFile).Msg("Benchmark baseline saved")
+	}
+
+	return exitCode
+}
+
+func newBaselineEntry(stats runner.BenchStats) benchBaselineEntry {
+	return benchBaselineEntry{
+		Runs:        stats.Runs,
+		MinNS:       stats.Min.Nanoseconds(),
+		MedianNS:    stats.Median.Nanoseconds(),
+		P95NS:       stats.P95.Nanoseconds(),
+		MaxNS:       stats.Max.Nanoseconds(),
+		AllocsPerOp: stats.AllocsPerOp,
+		BytesPerOp:  stats.BytesPerOp,
+	}
+}
+
+// compareToBaseline logs how the stats compare to the baseline, returning true
+// if the median is slower than the baseline by more than the threshold
+func compareToBaseline(key string, baseline benchBaselineEntry, stats runner.BenchStats, threshold float64) (regressed bool) {
+	previous := time.Duration(baseline.MedianNS)
+	if previous <= 0 {
+		return false
+	}
+
+	change := float64(stats.Median-previous) / float64(previous)
+	event := log.Info()
+	if change > threshold {
+		event = log.Error()
+		regressed = true
+	}
+
+	event.
+		Str("stage", key).
+		Str("baseline", previous.String()).
+		Str("median", stats.Median.String()).
+		Str("change", fmt.Sprintf("%+.1f%%", change*100)).
+		Msg("Compared to baseline")
+
+	return regressed
+}
+
+// validate checks the configuration is usable
+func (c benchConfig) validate() error {
+	if c.opts.MaxRuns < 1 {
+		return errors.Newf("--bench-count must be at least 1, got %d", c.opts.MaxRuns)
+	}
+
+	if c.threshold < 0 {
+		return errors.Newf("--bench-threshold must not be negative, got %v", c.threshold)
+	}
+
+	return nil
+}
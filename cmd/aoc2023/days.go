@@ -0,0 +1,25 @@
+// Code generated by "gendays"; DO NOT EDIT.
+
+package main
+
+import (
+	// Register all the days
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day01"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day02"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day03"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day04"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day05"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day06"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day07"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day08"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day09"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day10"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day11"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day12"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day13"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day14"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day15"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day16"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day17"
+	_ "github.com/DomBlack/advent-of-code-2023/internal/day18"
+)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+)
+
+// runGen implements the gen subcommand, which writes a random input for a day
+// using the generator registered with the day
+func runGen(args []string) int {
+	id := runner.DayID{Year: runner.DefaultYear}
+	var size int
+	var seed int64
+	var output string
+	flags := pflag.NewFlagSet("gen", pflag.ExitOnError)
+	flags.IntVarP(&id.Year, "year", "y", runner.DefaultYear, "The year of the day to generate an input for")
+	flags.IntVarP(&id.Day, "day", "d", 0, "The day to generate an input for")
+	flags.IntVar(&size, "size", 10, "How large an input to generate, such as the width of a grid or the number of lines")
+	flags.Int64Var(&seed, "seed", 0, "The seed for the random input (defaults to a random seed, which is logged)")
+	flags.StringVarP(&output, "output", "o", "", "Write the input to this file rather than stdout")
+	_ = flags.Parse(args)
+
+	// Keep stdout for the input
+	log.Logger = log.Output(zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+		w.Out = os.Stderr
+	}))
+
+	if !flags.Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+
+	days, err := runner.AllDays(id.Year)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid day registrations")
+		return 1
+	}
+
+	var day runner.RunnableDay
+	for _, d := range days {
+		if d.Day() == id.Day {
+			day = d
+		}
+	}
+	if day == nil {
+		log.Error().Stringer("day", id).Msg("Day not found")
+		return 2
+	}
+
+	input, err := day.Generate(rand.New(rand.NewSource(seed)), size)
+	if err != nil {
+		log.Error().Err(err).Stringer("day", id).Msg("Failed to generate input")
+		return 1
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(input)
+	} else {
+		err = os.WriteFile(output, input, 0644)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to write input")
+		return 1
+	}
+
+	log.Info().Stringer("day", id).Int("size", size).Int64("seed", seed).Msg("Input generated")
+	return 0
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/cockroachdb/errors"
+)
+
+// guessSpec is a parsed --guess flag
+type guessSpec struct {
+	part    int
+	verdict runner.Verdict
+}
+
+// parseGuessSpecs parses the values of the --guess flag, which
+// are in the form "part=verdict"
+func parseGuessSpecs(values []string) ([]guessSpec, error) {
+	specs := make([]guessSpec, 0, len(values))
+
+	for _, value := range values {
+		partStr, verdictStr, found := strings.Cut(value, "=")
+		if !found {
+			return nil, errors.Newf("invalid guess %q, expected part=verdict", value)
+		}
+
+		part, err := strconv.Atoi(partStr)
+		if err != nil || (part != 1 && part != 2) {
+			return nil, errors.Newf("invalid part %q in guess %q, expected 1 or 2", partStr, value)
+		}
+
+		verdict, err := runner.ParseVerdict(verdictStr)
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, guessSpec{part: part, verdict: verdict})
+	}
+
+	return specs, nil
+}
+
+// recordGuesses records the verdicts of the guesses against the answers in the results
+//
+// The results must be from running a single day against a single input.
+func recordGuesses(guesses *runner.GuessLog, specs []guessSpec, results []runner.DayResult) error {
+	if len(results) != 1 {
+		return errors.Newf("guesses can only be recorded when running a single input, got %d inputs (use --input to choose one)", len(results))
+	}
+	result := results[0]
+
+	for _, spec := range specs {
+		var part *runner.PartResult
+		for i := range result.Parts {
+			if result.Parts[i].Part == spec.part {
+				part = &result.Parts[i]
+			}
+		}
+
+		if part == nil || part.Err != nil || part.Status == runner.StatusNotImplemented {
+			return errors.Newf("%s part %d did not return an answer to record a guess against", result.ID(), spec.part)
+		}
+
+		guesses.Record(result.ID(), result.Input, spec.part, part.Answer, spec.verdict)
+	}
+
+	return nil
+}
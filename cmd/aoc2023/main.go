@@ -7,39 +7,75 @@ import (
 	"time"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/mattn/go-isatty"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/spf13/pflag"
-
-	// Register all the days
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day01"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day02"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day03"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day04"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day05"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day06"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day07"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day08"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day09"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day10"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day11"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day12"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day13"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day14"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day15"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day16"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day17"
-	_ "github.com/DomBlack/advent-of-code-2023/internal/day18"
 )
 
+//go:generate go run ../gendays
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "new":
+			os.Exit(runNew(os.Args[2:]))
+		case "watch":
+			os.Exit(runWatch(os.Args[2:]))
+		case "gen":
+			os.Exit(runGen(os.Args[2:]))
+		}
+	}
+
+	var onlyYear int
 	var onlyDay int
 	var verboseLevel int
-	var saveOutput bool
+	var runOpts runner.RunOptions
+	var parallel int
+	var reports []string
+	var answersFile string
+	var recordAnswers bool
+	var guessesFile string
+	var guesses []string
+	var heapBudgetMiB uint64
+	var profileCfg profileConfig
+	var partProfiles []string
+	var bench bool
+	var benchCfg benchConfig
+	var verify bool
+	var verifyOpts runner.VerifyOptions
+	pflag.IntVarP(&onlyYear, "year", "y", 0, "Only run days from this year")
 	pflag.IntVarP(&onlyDay, "day", "d", 0, "Only run this day")
 	pflag.CountVarP(&verboseLevel, "verbose", "v", "Increase verbosity")
-	pflag.BoolVarP(&saveOutput, "save-output", "s", false, "Save output to file")
+	pflag.BoolVarP(&runOpts.SaveOutput, "save-output", "s", false, "Save output to file")
+	pflag.StringVarP(&runOpts.File, "input", "i", "", "Read the input from this file (or stdin if -) rather than the inputs directory, requires --day")
+	pflag.StringVar(&runOpts.Dir, "inputs-dir", "", "Directory to read inputs from (defaults to the inputs directory in the repo)")
+	pflag.BoolVar(&runOpts.StreamInputs, "stream-inputs", false, "Give streaming days a stream reading the input file for each part, rather than reading it into memory first")
+	pflag.IntVar(&runOpts.MaxTokenSize, "max-token-size", 0, "The longest line, in bytes, a streamed input can contain (0 for the 64KiB default)")
+	pflag.IntVarP(&parallel, "parallel", "p", 1, "Number of days to run concurrently")
+	pflag.StringArrayVar(&reports, "report", nil, "Write a report of the results as format=path, where format is json or junit (can be repeated)")
+	pflag.StringVar(&answersFile, "answers", runner.DefaultAnswersFile(), "File of expected answers to check against")
+	pflag.BoolVar(&recordAnswers, "record-answers", false, "Record the answers of all passing parts into the answers file")
+	pflag.StringVar(&guessesFile, "guesses", runner.DefaultGuessLogFile(), "File recording previously submitted answers and their verdicts")
+	pflag.StringArrayVar(&guesses, "guess", nil, "Record the verdict for the answer this run gives as part=verdict, where verdict is correct, too-high, too-low or wrong (can be repeated, requires --day)")
+	pflag.DurationVar(&runOpts.Limits.Timeout, "timeout", 0, "Fail any part which runs for longer than this, unless the day sets its own timeout (0 for no limit)")
+	pflag.Uint64Var(&heapBudgetMiB, "heap-budget", 0, "Fail any part whose live heap grows by more than this many MiB, unless the day sets its own budget (0 for no limit)")
+	pflag.StringVar(&profileCfg.cpuFile, "cpuprofile", "", "Write a CPU profile of the whole run to this file")
+	pflag.StringVar(&profileCfg.memFile, "memprofile", "", "Write a memory profile to this file once all days have run")
+	pflag.StringVar(&profileCfg.traceFile, "trace", "", "Write an execution trace of the whole run to this file")
+	pflag.StringSliceVar(&partProfiles, "profile-per-part", nil, "Record these profiles (cpu, mem or trace) around each part, writing them to the outputs directory")
+	pflag.BoolVar(&bench, "bench", false, "Benchmark the days rather than running them once")
+	pflag.IntVar(&benchCfg.opts.MaxRuns, "bench-count", 100, "Maximum number of times to run each stage when benchmarking")
+	pflag.DurationVar(&benchCfg.opts.TargetDur, "bench-time", time.Second, "Stop benchmarking a stage after this long")
+	pflag.StringVar(&benchCfg.baselineFile, "bench-baseline", "", "Compare benchmark results against this baseline file")
+	pflag.StringVar(&benchCfg.saveFile, "bench-save", "", "Save benchmark results as a baseline file")
+	pflag.Float64Var(&benchCfg.threshold, "bench-threshold", 0.1, "Fraction the median can slow down by before being flagged as a regression")
+	pflag.BoolVar(&verify, "verify", false, "Check the days parts against their reference implementations rather than running them")
+	pflag.IntVar(&verifyOpts.MaxInputSize, "verify-max-size", 0, "Also verify against inputs up to this many bytes, as well as the examples")
+	pflag.IntVar(&verifyOpts.Generated, "verify-generated", 10, "How many generated inputs to verify against, for days with a generator")
+	pflag.IntVar(&verifyOpts.GenerateSize, "verify-size", 10, "The size of the generated inputs to verify against")
+	pflag.Int64Var(&verifyOpts.Seed, "verify-seed", 1, "The seed of the first generated input to verify against")
 	pflag.Parse()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
@@ -51,28 +87,174 @@ func main() {
 	}
 	log.Logger = log.Level(newLevel)
 
+	runOpts.Limits.HeapBudget = heapBudgetMiB << 20
+	if heapBudgetMiB > 0 && parallel > 1 {
+		// The live heap is measured across the whole process, so concurrent days would count against each other
+		log.Fatal().Msg("--heap-budget can't be used with --parallel")
+	}
+
+	// Only draw live progress when the days logs aren't being buffered
+	if parallel <= 1 && isatty.IsTerminal(os.Stdout.Fd()) {
+		runOpts.LiveProgress = os.Stdout
+	}
+
+	reportSpecs, err := parseReportSpecs(reports)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid --report flag")
+	}
+
+	runOpts.Answers, err = runner.LoadAnswersFile(answersFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load answers file")
+	}
+
+	runOpts.Guesses, err = runner.LoadGuessLog(guessesFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load guess log")
+	}
+
+	guessSpecs, err := parseGuessSpecs(guesses)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid --guess flag")
+	}
+	if len(guessSpecs) > 0 && onlyDay == 0 {
+		log.Fatal().Msg("--guess can only be used with --day")
+	}
+
+	if runOpts.File != "" && onlyDay == 0 {
+		log.Fatal().Msg("--input can only be used with --day")
+	}
+
+	runOpts.Profiles, err = runner.ParseProfiles(partProfiles)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid --profile-per-part flag")
+	}
+	if (runOpts.Profiles.CPU && profileCfg.cpuFile != "") || (runOpts.Profiles.Trace && profileCfg.traceFile != "") {
+		log.Fatal().Msg("--profile-per-part can't record the same profile as --cpuprofile or --trace")
+	}
+	if (runOpts.Profiles.CPU || runOpts.Profiles.Trace) && parallel > 1 {
+		log.Fatal().Msg("--profile-per-part can't record cpu profiles or traces with --parallel")
+	}
+
+	if bench {
+		if err := benchCfg.validate(); err != nil {
+			log.Fatal().Err(err).Msg("Invalid benchmark flags")
+		}
+		benchCfg.opts.InputOptions = runOpts.InputOptions
+	}
+
+	if verify {
+		if bench {
+			log.Fatal().Msg("--verify can't be used with --bench")
+		}
+		verifyOpts.InputOptions = runOpts.InputOptions
+	}
+
+	var years []int
+	if onlyYear != 0 {
+		years = append(years, onlyYear)
+	}
+
 	if onlyDay != 0 {
-		log.Info().Int("day", onlyDay).Msg("Only running single day")
+		log.Info().Int("day", onlyDay).Ints("years", years).Msg("Only running single day")
 	} else {
-		log.Info().Msg("Running all days")
+		log.Info().Ints("years", years).Msg("Running all days")
 	}
 
-	days := runner.AllDays()
-	runCount := 0
+	allDays, err := runner.AllDays(years...)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid day registrations")
+	}
+
+	var days []runner.RunnableDay
+	for _, day := range allDays {
+		if onlyDay == 0 || day.Day() == onlyDay {
+			days = append(days, day)
+		}
+	}
+
+	if runOpts.File != "" && len(days) > 1 {
+		log.Fatal().Int("day", onlyDay).Msg("--input matches the day in multiple years, use --year to pick one")
+	}
+
+	stopProfiling, err := profileCfg.start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to start profiling")
+	}
+
+	if bench {
+		exitCode := runBenchmarks(ctx, days, benchCfg)
+		stopProfiling()
+		os.Exit(exitCode)
+	}
+
+	if verify {
+		exitCode := runVerify(ctx, days, verifyOpts)
+		stopProfiling()
+		os.Exit(exitCode)
+	}
 
 	start := time.Now()
-	for _, day := range days {
-		if ctx.Err() != nil {
-			log.Warn().Err(ctx.Err()).Msg("Cancelled")
-			return
+	results := runDays(ctx, days, parallel, runOpts)
+	dur := time.Since(start)
+	stopProfiling()
+
+	if ctx.Err() != nil {
+		log.Warn().Err(ctx.Err()).Msg("Cancelled")
+		os.Exit(1)
+	}
+
+	for _, report := range reportSpecs {
+		if err := report.write(results, dur); err != nil {
+			log.Error().Err(err).Str("path", report.path).Msg("Failed to write report")
+		} else {
+			log.Info().Str("format", report.format).Str("path", report.path).Msg("Report written")
 		}
+	}
 
-		if onlyDay == 0 || day.Day() == onlyDay {
-			day.Run(ctx, saveOutput)
-			runCount++
+	if len(guessSpecs) > 0 {
+		if err := recordGuesses(runOpts.Guesses, guessSpecs, results); err != nil {
+			log.Error().Err(err).Msg("Failed to record guesses")
+		} else if err := runOpts.Guesses.Save(guessesFile); err != nil {
+			log.Error().Err(err).Str("path", guessesFile).Msg("Failed to save guess log")
+		} else {
+			log.Info().Int("guesses", len(guessSpecs)).Msg("Guesses recorded")
 		}
 	}
-	dur := time.Since(start)
 
-	log.Info().Int("days", runCount).Str("duration", dur.String()).Msg("Finished running days")
+	if recordAnswers {
+		changes := runOpts.Answers.Record(results)
+		for _, change := range changes {
+			log.Info().Msgf("Recorded answer for %s", change)
+		}
+
+		if len(changes) > 0 {
+			if err := runOpts.Answers.Save(answersFile); err != nil {
+				log.Error().Err(err).Str("path", answersFile).Msg("Failed to save answers file")
+			}
+		}
+	}
+
+	summary := runner.Summarise(results)
+	for _, result := range results {
+		if result.Err != nil {
+			log.Error().Int("year", result.Year).Int("day", result.Day).Str("input", result.Input).Err(result.Err).Msg("Day failed to run")
+		}
+
+		for _, part := range result.Parts {
+			if part.Status.Failed() {
+				log.Error().Int("year", result.Year).Int("day", result.Day).Str("input", result.Input).Int("part", part.Part).Str("status", string(part.Status)).Msg("Part failed")
+			}
+		}
+	}
+
+	log.Info().
+		Int("days", summary.Days).
+		Int("passed", summary.Passed).
+		Int("failed", summary.Failed).
+		Int("not_implemented", summary.NotImplemented).
+		Str("duration", dur.String()).
+		Msg("Finished running days")
+
+	os.Exit(summary.ExitCode())
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/DomBlack/advent-of-code-2023/internal/scaffold"
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+)
+
+// runNew implements the new subcommand, which generates the package, test and
+// empty input file for a day and then registers it with the main command.
+func runNew(args []string) int {
+	var day scaffold.Day
+	flags := pflag.NewFlagSet("new", pflag.ExitOnError)
+	flags.IntVarP(&day.Year, "year", "y", runner.DefaultYear, "The year of the day to generate")
+	flags.IntVarP(&day.Day, "day", "d", 0, "The day to generate")
+	flags.BoolVar(&day.Grid, "grid", false, "Parse the input as a grid of tiles")
+	flags.BoolVar(&day.Streaming, "streaming", false, "Parse the input as a stream")
+	_ = flags.Parse(args)
+
+	if day.Day < 1 || day.Day > 25 {
+		log.Error().Int("day", day.Day).Msg("--day must be between 1 and 25")
+		return 2
+	}
+
+	if err := day.Generate(runner.RepoDir()); err != nil {
+		log.Error().Err(err).Int("year", day.Year).Int("day", day.Day).Msg("Failed to generate day")
+		return 1
+	}
+
+	log.Info().Int("year", day.Year).Int("day", day.Day).Msg("Day generated")
+	return 0
+}
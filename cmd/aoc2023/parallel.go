@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// dayRun tracks a single day being run on the worker pool
+type dayRun struct {
+	day     runner.RunnableDay
+	output  bytes.Buffer       // buffered log output from the day
+	results []runner.DayResult // the results of running the day against each input
+	done    chan struct{}      // closed once the day has finished
+}
+
+// runDays runs the given days on a pool of workers, printing the
+// log output of each day in day order once that day has finished.
+//
+// It returns the results of each day in day order.
+func runDays(ctx context.Context, days []runner.RunnableDay, workers int, opts runner.RunOptions) []runner.DayResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	runs := make([]*dayRun, len(days))
+	jobs := make(chan *dayRun)
+	for i, day := range days {
+		runs[i] = &dayRun{day: day, done: make(chan struct{})}
+	}
+
+	// Start the workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for run := range jobs {
+				run.execute(ctx, workers > 1, opts)
+			}
+		}()
+	}
+
+	// Feed the days to the workers in order
+	go func() {
+		defer close(jobs)
+
+		for _, run := range runs {
+			select {
+			case jobs <- run:
+			case <-ctx.Done():
+				// Mark the remaining days as cancelled
+				run.results = []runner.DayResult{{Year: run.day.Year(), Day: run.day.Day(), Err: ctx.Err()}}
+				close(run.done)
+			}
+		}
+	}()
+
+	// Print the results as they complete, in day order
+	results := make([]runner.DayResult, 0, len(runs))
+	for _, run := range runs {
+		<-run.done
+
+		if run.output.Len() > 0 {
+			_, _ = run.output.WriteTo(os.Stdout)
+		}
+
+		results = append(results, run.results...)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// execute runs the day, buffering it's log output if requested
+func (r *dayRun) execute(ctx context.Context, buffered bool, opts runner.RunOptions) {
+	defer close(r.done)
+
+	if ctx.Err() != nil {
+		r.results = []runner.DayResult{{Year: r.day.Year(), Day: r.day.Day(), Err: ctx.Err()}}
+		return
+	}
+
+	if buffered {
+		logger := log.Output(zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+			w.Out = &r.output
+		}))
+		ctx = logger.WithContext(ctx)
+	}
+
+	r.results = r.day.Run(ctx, opts)
+}
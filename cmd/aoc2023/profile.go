@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// profileConfig is the set of profiles to record for the whole run
+type profileConfig struct {
+	cpuFile   string // Write a CPU profile to this file
+	memFile   string // Write a heap profile to this file once all days have run
+	traceFile string // Write an execution trace to this file
+}
+
+// start begins recording the profiles, returning a function which stops them and writes the results
+func (c profileConfig) start() (stop func(), err error) {
+	var stops []func()
+	stop = func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+
+	if c.cpuFile != "" {
+		f, err := os.Create(c.cpuFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create cpu profile")
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, errors.Wrap(err, "failed to start cpu profile")
+		}
+
+		stops = append(stops, func() {
+			pprof.StopCPUProfile()
+			closeProfile(f)
+		})
+	}
+
+	if c.traceFile != "" {
+		f, err := os.Create(c.traceFile)
+		if err != nil {
+			stop()
+			return nil, errors.Wrap(err, "failed to create trace")
+		}
+
+		if err := trace.Start(f); err != nil {
+			_ = f.Close()
+			stop()
+			return nil, errors.Wrap(err, "failed to start trace")
+		}
+
+		stops = append(stops, func() {
+			trace.Stop()
+			closeProfile(f)
+		})
+	}
+
+	if c.memFile != "" {
+		stops = append(stops, func() {
+			f, err := os.Create(c.memFile)
+			if err != nil {
+				log.Error().Err(err).Str("path", c.memFile).Msg("Failed to create memory profile")
+				return
+			}
+
+			runtime.GC() // get up-to-date statistics
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				log.Error().Err(err).Str("path", c.memFile).Msg("Failed to write memory profile")
+			}
+			closeProfile(f)
+		})
+	}
+
+	return stop, nil
+}
+
+// closeProfile closes the profile file, logging if it fails
+func closeProfile(f *os.File) {
+	if err := f.Close(); err != nil {
+		log.Error().Err(err).Str("path", f.Name()).Msg("Failed to write profile")
+	} else {
+		log.Info().Str("path", f.Name()).Msg("Profile written")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/cockroachdb/errors"
+)
+
+// reportWriters maps the format names accepted by --report to the
+// function which writes that format
+var reportWriters = map[string]func(w io.Writer, results []runner.DayResult, duration time.Duration) error{
+	"json":  runner.WriteJSONReport,
+	"junit": runner.WriteJUnitReport,
+}
+
+// reportSpec is a parsed --report flag
+type reportSpec struct {
+	format string
+	path   string
+}
+
+// parseReportSpecs parses the values of the --report flag, which
+// are in the form "format=path"
+func parseReportSpecs(values []string) ([]reportSpec, error) {
+	specs := make([]reportSpec, 0, len(values))
+
+	for _, value := range values {
+		format, path, found := strings.Cut(value, "=")
+		if !found || path == "" {
+			return nil, errors.Newf("invalid report %q, expected format=path", value)
+		}
+
+		if _, ok := reportWriters[format]; !ok {
+			return nil, errors.Newf("unknown report format %q, expected json or junit", format)
+		}
+
+		specs = append(specs, reportSpec{format: format, path: path})
+	}
+
+	return specs, nil
+}
+
+// write writes the report to the path given in the spec
+func (r reportSpec) write(results []runner.DayResult, duration time.Duration) (err error) {
+	f, err := os.Create(r.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s report", r.format)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errors.Wrapf(closeErr, "failed to close %s report", r.format)
+		}
+	}()
+
+	return reportWriters[r.format](f, results, duration)
+}
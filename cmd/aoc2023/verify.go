@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog/log"
+)
+
+// runVerify checks each of the given days against their reference implementations,
+// returning the exit code to use
+//
+// A day with references which weren't checked against any input counts as failed.
+func runVerify(ctx context.Context, days []runner.RunnableDay, opts runner.VerifyOptions) int {
+	checked, mismatches, failed := 0, 0, 0
+	for _, day := range days {
+		if ctx.Err() != nil {
+			log.Warn().Err(ctx.Err()).Msg("Cancelled")
+			return 1
+		}
+
+		result := day.Verify(ctx, opts)
+		checked += result.Checked
+		mismatches += len(result.Mismatches)
+		if result.Err != nil {
+			failed++
+		} else if result.References && result.Checked == 0 {
+			// A reference which is never compared against verifies nothing, so treat it as a failure
+			log.Warn().Stringer("day", result.ID()).Int("skipped", result.Skipped).Msg("day has references but no parts were checked against them")
+			failed++
+		}
+	}
+
+	event := log.Info()
+	if mismatches > 0 || failed > 0 {
+		event = log.Error()
+	}
+	event.Int("checked", checked).Int("mismatches", mismatches).Int("failed_days", failed).Msg("Verification complete")
+
+	if mismatches > 0 || failed > 0 {
+		return 1
+	}
+	return 0
+}
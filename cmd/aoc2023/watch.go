@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+)
+
+// fileState is the modification time and size of a watched file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher re-runs a day whenever its source or inputs change
+type watcher struct {
+	id        runner.DayID
+	inputsDir string
+	interval  time.Duration
+	previous  map[string]runner.PartResult // The results of the last run, keyed by input and part
+}
+
+// runWatch implements the watch subcommand, which polls the days package and inputs
+// for changes, re-running the days tests and the day itself each time they change.
+func runWatch(args []string) int {
+	w := watcher{id: runner.DayID{Year: runner.DefaultYear}}
+	flags := pflag.NewFlagSet("watch", pflag.ExitOnError)
+	flags.IntVarP(&w.id.Year, "year", "y", runner.DefaultYear, "The year of the day to watch")
+	flags.IntVarP(&w.id.Day, "day", "d", 0, "The day to watch")
+	flags.StringVar(&w.inputsDir, "inputs-dir", "", "Directory to read inputs from (defaults to the inputs directory in the repo)")
+	flags.DurationVar(&w.interval, "interval", 500*time.Millisecond, "How often to check for changes")
+	_ = flags.Parse(args)
+
+	if w.id.Day < 1 || w.id.Day > 25 {
+		log.Error().Int("day", w.id.Day).Msg("--day must be between 1 and 25")
+		return 2
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	if err := w.watch(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Error().Err(err).Msg("Failed to watch day")
+		return 1
+	}
+
+	return 0
+}
+
+// watch runs the day, then re-runs it each time the watched files change until the context is cancelled
+func (w *watcher) watch(ctx context.Context) error {
+	log.Info().Stringer("day", w.id).Str("package", w.id.PackageDir()).Str("inputs", w.id.InputDir(w.inputsDir)).Msg("Watching for changes")
+
+	last, err := w.scan()
+	if err != nil {
+		return err
+	}
+	w.run(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := w.scan()
+		if err != nil {
+			return err
+		}
+
+		if changed := changedFiles(last, current); len(changed) > 0 {
+			log.Info().Strs("files", changed).Msg("Changes detected, re-running")
+			w.run(ctx)
+
+			// Rescan so that any changes made while running are picked up on the next tick
+			if current, err = w.scan(); err != nil {
+				return err
+			}
+		}
+		last = current
+	}
+}
+
+// scan returns the state of every watched file
+//
+// Files directly within the testdata directory of the package are skipped, as running the tests
+// writes its golden outputs there, however the examples within testdata/examples are watched.
+func (w *watcher) scan() (map[string]fileState, error) {
+	files := make(map[string]fileState)
+
+	record := func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Base(filepath.Dir(path)) == "testdata" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	}
+
+	inputDir := w.id.InputDir(w.inputsDir)
+	dayName := fmt.Sprintf("day%02d", w.id.Day)
+	for _, root := range []string{
+		w.id.PackageDir(),
+		filepath.Join(inputDir, dayName+".txt"),
+		filepath.Join(inputDir, dayName),
+	} {
+		if err := filepath.WalkDir(root, record); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan %s", root)
+		}
+	}
+
+	return files, nil
+}
+
+// changedFiles returns the files which have been added, removed or modified
+func changedFiles(before, after map[string]fileState) (changed []string) {
+	for path, state := range after {
+		if previous, found := before[path]; !found || previous != state {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range before {
+		if _, found := after[path]; !found {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
+
+// run runs the days tests and then the day itself, logging how the answers
+// and timings differ from the previous run
+func (w *watcher) run(ctx context.Context) {
+	pkg, err := filepath.Rel(runner.RepoDir(), w.id.PackageDir())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find package")
+		return
+	}
+
+	if err := w.goCommand(ctx, "test", "./"+filepath.ToSlash(pkg)); err != nil {
+		log.Error().Err(err).Msg("Tests failed")
+	} else {
+		log.Info().Msg("Tests passed")
+	}
+
+	reportFile, err := os.CreateTemp("", "aoc2023-watch-*.json")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create report file")
+		return
+	}
+	_ = reportFile.Close()
+	defer func() { _ = os.Remove(reportFile.Name()) }()
+
+	args := []string{
+		"run", "./cmd/aoc2023",
+		"--year", strconv.Itoa(w.id.Year),
+		"--day", strconv.Itoa(w.id.Day),
+		"--report", "json=" + reportFile.Name(),
+	}
+	if w.inputsDir != "" {
+		args = append(args, "--inputs-dir", w.inputsDir)
+	}
+
+	if err := w.goCommand(ctx, args...); err != nil && ctx.Err() == nil {
+		log.Warn().Err(err).Msg("Day did not pass")
+	}
+
+	f, err := os.Open(reportFile.Name())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open report")
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	results, _, err := runner.ReadJSONReport(f)
+	if err != nil {
+		// The day most likely failed to build
+		log.Error().Err(err).Msg("Failed to read report")
+		return
+	}
+
+	w.logDiff(results)
+}
+
+// goCommand runs the go tool in the root of the repo, with its output going to stdout and stderr
+func (w *watcher) goCommand(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = runner.RepoDir()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// logDiff logs the answers and timings of each part, compared to the previous run
+func (w *watcher) logDiff(results []runner.DayResult) {
+	current := make(map[string]runner.PartResult)
+
+	for _, result := range results {
+		for _, part := range result.Parts {
+			key := fmt.Sprintf("%s/part%d", result.Input, part.Part)
+			current[key] = part
+
+			level := zerolog.InfoLevel
+			if part.Status.Failed() {
+				level = zerolog.WarnLevel
+			}
+			event := log.WithLevel(level).
+				Str("input", result.Input).
+				Int("part", part.Part).
+				Str("status", string(part.Status)).
+				Stringer("answer", part.Answer).
+				Str("duration", part.Duration.String())
+
+			previous, found := w.previous[key]
+			if !found {
+				event.Msg("Part ran")
+				continue
+			}
+
+			if !previous.Answer.Equal(part.Answer) {
+				event = event.Stringer("previous_answer", previous.Answer)
+			}
+			if previous.Status != part.Status {
+				event = event.Str("previous_status", string(previous.Status))
+			}
+
+			if previous.Duration > 0 {
+				change := 100 * (float64(part.Duration) - float64(previous.Duration)) / float64(previous.Duration)
+				event = event.Str("previous_duration", previous.Duration.String()).Str("duration_change", fmt.Sprintf("%+.1f%%", change))
+			}
+
+			if previous.Answer.Equal(part.Answer) {
+				event.Msg("Part answer unchanged")
+			} else {
+				event.Msg("Part answer changed")
+			}
+		}
+	}
+
+	w.previous = current
+}
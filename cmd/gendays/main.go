@@ -0,0 +1,17 @@
+// Command gendays regenerates cmd/aoc2023/days.go, which imports every
+// internal/dayNN package so that each day is registered with the runner.
+//
+// It is run by go generate from the cmd/aoc2023 package.
+package main
+
+import (
+	"github.com/DomBlack/advent-of-code-2023/internal/scaffold"
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	if err := scaffold.WriteDaysFile(runner.RepoDir()); err != nil {
+		log.Fatal().Err(err).Msg("Failed to generate days file")
+	}
+}
@@ -1,12 +1,15 @@
 module github.com/DomBlack/advent-of-code-2023
 
-go 1.21
+go 1.23
 
 require (
 	github.com/cockroachdb/errors v1.11.1
+	github.com/mattn/go-isatty v0.0.19
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/rs/zerolog v1.31.0
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/image v0.14.0
 )
 
 require (
@@ -20,11 +23,8 @@ require (
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
-	golang.org/x/image v0.14.0 // indirect
 	golang.org/x/sys v0.12.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
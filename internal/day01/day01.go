@@ -1,6 +1,8 @@
 package day01
 
 import (
+	"io"
+
 	"github.com/DomBlack/advent-of-code-2023/pkg/datastructures/trie"
 	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
 	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
@@ -8,9 +10,13 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var Day01 = runner.NewStreamingDay(1, stream.LinesFrom, implementation(part1Tree), implementation(part2Tree)).
+var Day01 = runner.NewStreamingDay(1, parseLines, implementation(part1Tree), implementation(part2Tree)).
 	WithExpectedAnswers(54630, 54770)
 
+func parseLines(input io.Reader, options ...stream.ScannerOption) stream.Stream[string] {
+	return stream.LinesFromReader(input, options...)
+}
+
 var part1Tree = trie.New[int]().
 	MustInsert("0", 0).
 	MustInsert("1", 1).
@@ -1,6 +1,7 @@
 package day02
 
 import (
+	"io"
 	"strconv"
 	"strings"
 
@@ -41,8 +42,8 @@ func part2(_ *runner.Context, _ zerolog.Logger, games stream.Stream[Game]) (answ
 	return stream.Sum(cubePowers)
 }
 
-func parseGames(input []byte) stream.Stream[Game] {
-	lines := stream.LinesFrom(input)
+func parseGames(input io.Reader, options ...stream.ScannerOption) stream.Stream[Game] {
+	lines := stream.LinesFromReader(input, options...)
 
 	// Parse each game
 	return stream.Map(lines, func(line string) (game Game, err error) {
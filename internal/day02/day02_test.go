@@ -1,15 +1,25 @@
 package day02
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
 func Test_Day2(t *testing.T) {
-	input := `Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
-Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue
-Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red
-Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
-Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green`
+	Day02.TestExamples(t)
+}
+
+func FuzzParser(f *testing.F) {
+	Day02.FuzzParser(f, formatGames)
+}
+
+// formatGames prints the games as a single round of their maximum cubes
+func formatGames(games []Game) string {
+	lines := make([]string, len(games))
+	for i, game := range games {
+		lines[i] = fmt.Sprintf("Game %d: %d red, %d green, %d blue", game.ID, game.MaxRed, game.MaxGreen, game.MaxBlue)
+	}
 
-	Day02.Test(t, input, 8, input, 2286)
+	return strings.Join(lines, "\n")
 }
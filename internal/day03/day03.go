@@ -11,7 +11,8 @@ import (
 )
 
 var Day03 = runner.NewStreamingDay(3, parseSchematic, part1, part2).
-	WithExpectedAnswers(539590, 80703636)
+	WithExpectedAnswers(539590, 80703636).
+	WithGenerator(generateSchematic)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input stream.Stream[Token]) (answer int, err error) {
 	parts, numbers := stream.Partition(input, func(token Token) (bool, error) {
@@ -54,3 +54,7 @@ func Test_Day3(t *testing.T) {
 ..12..
 ......`, 0)
 }
+
+func Test_Generator(t *testing.T) {
+	Day03.TestGenerator(t)
+}
@@ -0,0 +1,41 @@
+package day03
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+)
+
+// symbols are the part symbols which appear in the schematics
+const symbols = "*#+$/@%&=-"
+
+// generateSchematic creates a square schematic of the given width, scattered with
+// numbers of up to three digits and part symbols
+func generateSchematic(rng *rand.Rand, size int) []byte {
+	var buf bytes.Buffer
+
+	for y := 0; y < size; y++ {
+		line := bytes.Repeat([]byte{'.'}, size)
+
+		for x := 0; x < size; x++ {
+			switch roll := rng.Float64(); {
+			case roll < 0.08:
+				line[x] = symbols[rng.Intn(len(symbols))]
+
+			case roll < 0.25:
+				number := strconv.Itoa(1 + rng.Intn(999))
+				if x+len(number) > size {
+					continue
+				}
+
+				copy(line[x:], number)
+				x += len(number) // Leave a gap so the next number doesn't join this one
+			}
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
 	"github.com/cockroachdb/errors"
@@ -53,9 +54,11 @@ type lexer struct {
 	column     int
 }
 
-func parseSchematic(input []byte) stream.Stream[Token] {
+// parseSchematic lexes the input a rune at a time, so has no limit on the length of a line
+// and ignores any scanner options
+func parseSchematic(input io.Reader, _ ...stream.ScannerOption) stream.Stream[Token] {
 	p := &lexer{
-		reader: bufio.NewReader(bytes.NewReader(input)),
+		reader: bufio.NewReader(input),
 		line:   1,
 		column: 1,
 	}
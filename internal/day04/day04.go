@@ -22,8 +22,8 @@ type Card struct {
 	PlayedNumbers  []int
 }
 
-func parseCards(input []byte) stream.Stream[*Card] {
-	return stream.Map(stream.LinesFrom(input), func(line string) (card *Card, err error) {
+func parseCards(input io.Reader, options ...stream.ScannerOption) stream.Stream[*Card] {
+	return stream.Map(stream.LinesFromReader(input, options...), func(line string) (card *Card, err error) {
 		if !strings.HasPrefix(line, "Card ") {
 			return nil, errors.Newf("line missing prefix: %q", line)
 		}
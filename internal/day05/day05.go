@@ -9,21 +9,15 @@ import (
 )
 
 var Day05 = runner.NewDay(5, parseMaps, part1, part2).
-	WithExpectedAnswers(226172555, 47909639)
+	WithExpectedAnswers(226172555, 47909639).
+	WithReferencePart2(part2BruteForce).
+	WithGenerator(generateAlmanac)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input Maps) (answer int, err error) {
 	lowest := math.MaxInt
 
 	for _, seed := range input.Seeds {
-		soil := input.SeedToSoil.Destination(seed)
-		fertilizer := input.SoilToFertilizer.Destination(soil)
-		water := input.FertilizerToWater.Destination(fertilizer)
-		light := input.WaterToLight.Destination(water)
-		temp := input.LightToTemperature.Destination(light)
-		humidity := input.TemperatureToHumidity.Destination(temp)
-		location := input.HumidityToLocation.Destination(humidity)
-
-		if lowest > location {
+		if location := input.Location(seed); lowest > location {
 			lowest = location
 		}
 	}
@@ -46,6 +40,16 @@ func part2(_ *runner.Context, _ zerolog.Logger, input Maps) (answer int, err err
 	}
 
 	seedToLocations := offsets.ToMap()
+	if len(seedToLocations) > 0 {
+		// Seeds past the end of the offsets pass through every map unchanged
+		last := seedToLocations[len(seedToLocations)-1]
+		end := last.SrcRangeStart + last.Length
+		seedToLocations = append(seedToLocations, Range{
+			SrcRangeStart:  end,
+			DestRangeStart: end,
+			Length:         math.MaxInt - end,
+		})
+	}
 
 	seedRanges := make([]Range, 0)
 	for i := 0; i < len(input.Seeds); i += 2 {
@@ -64,8 +68,10 @@ func part2(_ *runner.Context, _ zerolog.Logger, input Maps) (answer int, err err
 		for _, seedRange := range seedRanges {
 
 			if toLocation.SourcesOverLap(seedRange) {
-				if toLocation.DestRangeStart < lowest {
-					lowest = toLocation.DestRangeStart
+				// The seed range may start part way through the range
+				location := toLocation.DestRangeStart + max(0, seedRange.SrcRangeStart-toLocation.SrcRangeStart)
+				if location < lowest {
+					lowest = location
 				}
 				break
 			}
@@ -74,3 +80,23 @@ func part2(_ *runner.Context, _ zerolog.Logger, input Maps) (answer int, err err
 
 	return lowest, nil
 }
+
+// part2BruteForce finds the location of every seed in the seed ranges one at a time,
+// for checking the merged offsets used by part 2
+func part2BruteForce(ctx *runner.Context, _ zerolog.Logger, input Maps) (answer int, err error) {
+	lowest := math.MaxInt
+
+	for i := 0; i+1 < len(input.Seeds); i += 2 {
+		for seed := input.Seeds[i]; seed < input.Seeds[i]+input.Seeds[i+1]; seed++ {
+			if seed%(1<<16) == 0 && ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+
+			if location := input.Location(seed); lowest > location {
+				lowest = location
+			}
+		}
+	}
+
+	return lowest, nil
+}
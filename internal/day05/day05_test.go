@@ -43,3 +43,36 @@ humidity-to-location map:
 
 	Day05.Test(t, input, 35, input, 46)
 }
+
+func Test_Generator(t *testing.T) {
+	Day05.TestGenerator(t)
+}
+
+// Test_Day05_PartialOverlap covers a seed range starting part way through a range in
+// the merged maps, where the lowest location is not the start of that range
+func Test_Day05_PartialOverlap(t *testing.T) {
+	input := `
+seeds: 5 3
+
+seed-to-soil map:
+10 0 10
+`
+
+	Day05.Test(t, input, 13, input, 15)
+}
+
+// Test_Day05_Gaps covers seeds which pass through the gaps between, and past the end of,
+// the ranges in a map
+func Test_Day05_Gaps(t *testing.T) {
+	input := `
+seeds: 20 5
+
+seed-to-soil map:
+0 10 5
+
+soil-to-fertilizer map:
+100 20 5
+`
+
+	Day05.Test(t, input, 5, input, 100)
+}
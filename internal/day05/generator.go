@@ -0,0 +1,65 @@
+package day05
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// mapNames are the maps in the order they appear in the almanac
+var mapNames = []string{
+	"seed-to-soil",
+	"soil-to-fertilizer",
+	"fertilizer-to-water",
+	"water-to-light",
+	"light-to-temperature",
+	"temperature-to-humidity",
+	"humidity-to-location",
+}
+
+// generateAlmanac creates an almanac with the given number of seed ranges and ranges in each map
+//
+// Like the puzzle inputs, the ranges in each map don't overlap but leave gaps between them,
+// which numbers pass through unchanged.
+func generateAlmanac(rng *rand.Rand, size int) []byte {
+	const maxRangeLength = 100
+	universe := size * maxRangeLength
+
+	var sb strings.Builder
+	sb.WriteString("seeds:")
+	for i := 0; i < size; i++ {
+		_, _ = fmt.Fprintf(&sb, " %d %d", rng.Intn(universe), 1+rng.Intn(maxRangeLength))
+	}
+	sb.WriteString("\n")
+
+	for _, name := range mapNames {
+		_, _ = fmt.Fprintf(&sb, "\n%s map:\n", name)
+
+		// Split the universe into ranges, then shuffle them to find where each one moves to
+		lengths := make([]int, 0, size)
+		for remaining := universe; remaining > 0; {
+			length := min(remaining, 1+rng.Intn(2*maxRangeLength))
+			lengths = append(lengths, length)
+			remaining -= length
+		}
+
+		order := rng.Perm(len(lengths))
+		destStarts := make([]int, len(lengths))
+		dest := 0
+		for _, i := range order {
+			destStarts[i] = dest
+			dest += lengths[i]
+		}
+
+		// Leave some of the ranges out of the map as gaps
+		src := 0
+		for i, length := range lengths {
+			if rng.Intn(4) != 0 {
+				_, _ = fmt.Fprintf(&sb, "%d %d %d\n", destStarts[i], src, length)
+			}
+			src += length
+		}
+	}
+
+	return []byte(sb.String())
+}
@@ -23,6 +23,17 @@ type Maps struct {
 	HumidityToLocation    Map
 }
 
+// Location follows the seed through each of the maps, returning its location
+func (m Maps) Location(seed int) int {
+	soil := m.SeedToSoil.Destination(seed)
+	fertilizer := m.SoilToFertilizer.Destination(soil)
+	water := m.FertilizerToWater.Destination(fertilizer)
+	light := m.WaterToLight.Destination(water)
+	temp := m.LightToTemperature.Destination(light)
+	humidity := m.TemperatureToHumidity.Destination(temp)
+	return m.HumidityToLocation.Destination(humidity)
+}
+
 type Map []Range
 
 func (m Map) String() string {
@@ -146,11 +157,8 @@ func parseMaps(input []byte) (rtn Maps, err error) {
 
 func parseMap(lines stream.Stream[string]) (rtn Map, err error) {
 	// Parse each range
-	for {
-		line, err := lines.Next()
-		if errors.Is(err, io.EOF) {
-			break
-		} else if err != nil {
+	for line, err := range stream.All(lines) {
+		if err != nil {
 			return nil, errors.Wrap(err, "unable to parse map")
 		}
 
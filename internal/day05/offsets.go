@@ -146,6 +146,12 @@ func MapsToMergedOffset(maps ...Map) (rtn Offsets, err error) {
 			continue
 		}
 
+		// Numbers past the end of the merged offsets pass through unchanged, so they
+		// must be extended to cover the new offsets before merging them
+		if len(newOffsets) > 0 && newOffsets[len(newOffsets)-1].InputTo > rtn[len(rtn)-1].InputTo {
+			rtn = append(rtn, Offset{InputTo: newOffsets[len(newOffsets)-1].InputTo, OffsetBy: 0})
+		}
+
 		newRtn := make(Offsets, 0, max(len(rtn)+len(newOffsets)))
 		for i, currentOffset := range rtn {
 			// Get the range start / end (inclsuive) for the current offset
@@ -6,15 +6,36 @@ import (
 	"strings"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
 	"github.com/cockroachdb/errors"
 	"github.com/rs/zerolog"
 )
 
-var Day06 = runner.NewDay(6, parseBaseInput, part1, part2)
+var Day06 = runner.NewDay(6, parseBaseInput, part1, part2).
+	WithReferencePart1(part1BruteForce).
+	WithReferencePart2(part2BruteForce)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, err error) {
-	result := 1
+	return multiplyWinningMethods(input, Race.NumberWinningMethods)
+}
 
+func part2(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, err error) {
+	return singleRaceWinningMethods(input, Race.NumberWinningMethods)
+}
+
+// part1BruteForce tries every hold time for each race, for checking the binary search used by part 1
+func part1BruteForce(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, err error) {
+	return multiplyWinningMethods(input, Race.countWinningMethods)
+}
+
+// part2BruteForce tries every hold time for the race, for checking the binary search used by part 2
+func part2BruteForce(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, err error) {
+	return singleRaceWinningMethods(input, Race.countWinningMethods)
+}
+
+// multiplyWinningMethods multiplies together the number of ways to win each race,
+// using winningMethods to count them
+func multiplyWinningMethods(input baseInput, winningMethods func(Race) int) (answer int, err error) {
 	times := strings.Fields(input.TimeLine)
 	distances := strings.Fields(input.DistanceLine)
 
@@ -22,20 +43,21 @@ func part1(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, er
 		return 0, errors.Newf("mismatched number of times and distances: %d != %d", len(times), len(distances))
 	}
 
-	for i := range times {
-		race, err := parseRace(times[i], distances[i])
+	methods := stream.Map(stream.Zip(stream.From(times), stream.From(distances)), func(pair stream.Pair[string, string]) (int, error) {
+		race, err := parseRace(pair.First, pair.Second)
 		if err != nil {
 			return 0, err
 		}
 
-		result *= race.NumberWinningMethods()
-
-	}
+		return winningMethods(race), nil
+	})
 
-	return result, nil
+	return stream.Product(methods)
 }
 
-func part2(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, err error) {
+// singleRaceWinningMethods counts the number of ways to win the race given by ignoring the
+// spaces in the input, using winningMethods to count them
+func singleRaceWinningMethods(input baseInput, winningMethods func(Race) int) (answer int, err error) {
 	race, err := parseRace(
 		strings.ReplaceAll(input.TimeLine, " ", ""),
 		strings.ReplaceAll(input.DistanceLine, " ", ""),
@@ -44,7 +66,7 @@ func part2(_ *runner.Context, _ zerolog.Logger, input baseInput) (answer int, er
 		return 0, err
 	}
 
-	return race.NumberWinningMethods(), nil
+	return winningMethods(race), nil
 }
 
 type Race struct {
@@ -73,6 +95,17 @@ func (r Race) NumberWinningMethods() int {
 	return wins
 }
 
+// countWinningMethods counts the winning hold times one at a time
+func (r Race) countWinningMethods() (wins int) {
+	for hold := 0; hold <= r.Time; hold++ {
+		if hold*(r.Time-hold) > r.RecordDistance {
+			wins++
+		}
+	}
+
+	return wins
+}
+
 func parseRace(time, recordDistance string) (Race, error) {
 	timeInt, err := strconv.Atoi(time)
 	if err != nil {
@@ -5,10 +5,5 @@ import (
 )
 
 func Test_Day06(t *testing.T) {
-	input := `
-Time:      7  15   30
-Distance:  9  40  200
-`
-
-	Day06.Test(t, input, 288, input, 71503)
+	Day06.TestExamples(t)
 }
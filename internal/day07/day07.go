@@ -1,6 +1,7 @@
 package day07
 
 import (
+	"io"
 	"slices"
 	"strconv"
 	"strings"
@@ -111,8 +112,8 @@ func (h Hand) String() string {
 	return sb.String()
 }
 
-func parseHands(bytes []byte) stream.Stream[Hand] {
-	return stream.Map(stream.LinesFrom(bytes), func(line string) (Hand, error) {
+func parseHands(input io.Reader, options ...stream.ScannerOption) stream.Stream[Hand] {
+	return stream.Map(stream.LinesFromReader(input, options...), func(line string) (Hand, error) {
 		cardsStr, bidStr, found := strings.Cut(line, " ")
 		if !found {
 			return Hand{}, errors.Newf("Could not find bid in line: %q", line)
@@ -120,7 +121,11 @@ func parseHands(bytes []byte) stream.Stream[Hand] {
 
 		bid, err := strconv.Atoi(bidStr)
 		if err != nil {
-			return Hand{}, errors.Wrapf(err, "Could not parse bid: %q", bid)
+			return Hand{}, errors.Wrapf(err, "Could not parse bid: %q", bidStr)
+		}
+
+		if len(cardsStr) != len(Hand{}.Cards) {
+			return Hand{}, errors.Newf("Expected %d cards in line: %q", len(Hand{}.Cards), line)
 		}
 
 		groupings := map[uint8]uint8{}
@@ -140,6 +145,8 @@ func parseHands(bytes []byte) stream.Stream[Hand] {
 				cards[i] = 13
 			case 'A':
 				cards[i] = 14
+			default:
+				return Hand{}, errors.Newf("Invalid card %q in line: %q", cardsStr[i], line)
 			}
 
 			groupings[cards[i]]++
@@ -1,17 +1,27 @@
 package day07
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 )
 
 func Test_Day07(t *testing.T) {
-	input := `
-32T3K 765
-T55J5 684
-KK677 28
-KTJJT 220
-QQQJA 483
-`
+	Day07.TestExamples(t)
+}
+
+func FuzzParser(f *testing.F) {
+	Day07.FuzzParser(f, formatHands, "AAAAA 1\n2345 6", "23456 7", "J")
+}
+
+// formatHands prints the hands in the puzzle format
+func formatHands(hands []Hand) string {
+	lines := make([]string, len(hands))
+	for i, hand := range hands {
+		// Hand.String includes the type after the bid, which the puzzle input doesn't have
+		cards, _, _ := strings.Cut(hand.String(), " ")
+		lines[i] = cards + " " + strconv.Itoa(hand.Bid)
+	}
 
-	Day07.Test(t, input, 6440, input, 5905)
+	return strings.Join(lines, "\n")
 }
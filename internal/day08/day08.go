@@ -1,7 +1,7 @@
 package day08
 
 import (
-	"io"
+	"slices"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/maths"
 	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
@@ -10,7 +10,8 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var Day08 = runner.NewDay(8, parseMap, part1, part2)
+var Day08 = runner.NewDay(8, parseMap, part1, part2).
+	WithReferencePart2(part2BruteForce)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input Map) (answer int, err error) {
 	// Follow the instructions
@@ -64,6 +65,37 @@ func part2(_ *runner.Context, _ zerolog.Logger, input Map) (answer int, err erro
 	return maths.LCM(lengths), nil
 }
 
+// part2BruteForce moves every position in step until they all end with Z,
+// for checking the assumption part 2 makes that each path loops back to its start
+func part2BruteForce(ctx *runner.Context, _ zerolog.Logger, input Map) (answer int, err error) {
+	if len(input.NodesEndingWithA) == 0 {
+		return 0, nil
+	}
+
+	nodes := slices.Clone(input.NodesEndingWithA)
+	for steps := 0; ; steps++ {
+		if steps%(1<<16) == 0 && ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		allAtZ := true
+		for i, node := range nodes {
+			allAtZ = allAtZ && node.Name[2] == 'Z'
+
+			switch input.Instructions[steps%len(input.Instructions)] {
+			case Left:
+				nodes[i] = node.Left
+			case Right:
+				nodes[i] = node.Right
+			}
+		}
+
+		if allAtZ {
+			return steps, nil
+		}
+	}
+}
+
 type Instruction uint8
 
 const (
@@ -128,12 +160,8 @@ func parseMap(input []byte) (rtn Map, err error) {
 	}
 
 	// Loop over the lines constructing the map
-	for {
-		line, err := lines.Next()
+	for line, err := range stream.All(lines) {
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
 			return Map{}, errors.Wrap(err, "failed to read line")
 		}
 
@@ -1,6 +1,7 @@
 package day09
 
 import (
+	"io"
 	"strconv"
 	"strings"
 
@@ -81,8 +82,8 @@ func (h History) PreviousValue() (int, error) {
 	return rows[0][len(rows[0])-1], nil
 }
 
-func parseHistory(input []byte) stream.Stream[History] {
-	return stream.Map(stream.LinesFrom(input), func(line string) (History, error) {
+func parseHistory(input io.Reader, options ...stream.ScannerOption) stream.Stream[History] {
+	return stream.Map(stream.LinesFromReader(input, options...), func(line string) (History, error) {
 		numStrs := strings.Fields(line)
 
 		nums := make(History, len(numStrs))
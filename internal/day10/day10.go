@@ -13,7 +13,8 @@ import (
 )
 
 var Day10 = runner.NewDay(10, buildPipeMaze, part1, part2).
-	WithExpectedAnswers(6890, 453)
+	WithExpectedAnswers(6890, 453).
+	WithGenerator(generateMaze)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input Maze) (answer int, err error) {
 	return input.Length / 2, nil
@@ -92,3 +92,7 @@ func mustBuildMaze(t *testing.T, input string) Maze {
 	assert.NoError(t, err, "Error building maze")
 	return maze
 }
+
+func Test_Generator(t *testing.T) {
+	Day10.TestGenerator(t)
+}
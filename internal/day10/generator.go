@@ -0,0 +1,153 @@
+package day10
+
+import (
+	"math/rand"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/maps"
+)
+
+// nodeSpacing is how far apart the nodes of the tree the loop winds around are,
+// which keeps the sides of the loop along neighbouring branches from touching
+const nodeSpacing = 4
+
+// junkPipes are used to fill the tiles which aren't part of the loop
+const junkPipes = "..|-LJ7F"
+
+// directions are the offsets to the North, East, South and West
+var directions = []maps.Pos{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// pipes are the pipes connecting each pair of directions, indexed by the directions as a bitmask
+var pipes = map[int]byte{
+	1<<0 | 1<<2: '|',
+	1<<1 | 1<<3: '-',
+	1<<0 | 1<<1: 'L',
+	1<<0 | 1<<3: 'J',
+	1<<2 | 1<<3: '7',
+	1<<1 | 1<<2: 'F',
+}
+
+// generateMaze creates a square maze of roughly the given width containing a single loop
+//
+// The loop winds around the outside of a random spanning tree of evenly spaced nodes, so it
+// has plenty of turns, and every tile which isn't part of the loop is filled with junk pipes.
+func generateMaze(rng *rand.Rand, size int) []byte {
+	nodes := max(1, (size-1)/nodeSpacing)
+	width := nodeSpacing*nodes + 1
+
+	inBounds := func(pos maps.Pos) bool {
+		return pos[0] >= 0 && pos[0] < width && pos[1] >= 0 && pos[1] < width
+	}
+	index := func(pos maps.Pos) int { return pos[1]*width + pos[0] }
+
+	// Build a random spanning tree of the nodes using a depth first search, marking the tiles it covers
+	tree := make([]bool, width*width)
+	isTree := func(pos maps.Pos) bool { return inBounds(pos) && tree[index(pos)] }
+	nodePos := func(node maps.Pos) maps.Pos {
+		return maps.Pos{nodeSpacing*node[0] + 2, nodeSpacing*node[1] + 2}
+	}
+
+	visited := make(map[maps.Pos]bool)
+	stack := []maps.Pos{{rng.Intn(nodes), rng.Intn(nodes)}}
+	visited[stack[0]] = true
+	tree[index(nodePos(stack[0]))] = true
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+
+		var unvisited []maps.Pos
+		for _, dir := range directions {
+			next := node.Add(dir)
+			if next[0] >= 0 && next[0] < nodes && next[1] >= 0 && next[1] < nodes && !visited[next] {
+				unvisited = append(unvisited, next)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		visited[next] = true
+		stack = append(stack, next)
+
+		for pos, step := nodePos(node), next.Add(maps.Pos{-node[0], -node[1]}); pos != nodePos(next); pos = pos.Add(step) {
+			tree[index(pos.Add(step))] = true
+		}
+	}
+
+	// The loop is every tile touching the tree, including diagonally
+	onLoop := func(pos maps.Pos) bool {
+		if !inBounds(pos) || isTree(pos) {
+			return false
+		}
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if isTree(pos.Add(maps.Pos{dx, dy})) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	loopExits := func(pos maps.Pos) (exits []int) {
+		for dir, offset := range directions {
+			if onLoop(pos.Add(offset)) {
+				exits = append(exits, dir)
+			}
+		}
+		return exits
+	}
+
+	// Fill the maze with junk, then walk around the loop laying the pipes. The tile
+	// diagonally up and to the left of the first tree tile is always on the loop.
+	grid := make([]byte, width*width)
+	for i := range grid {
+		grid[i] = junkPipes[rng.Intn(len(junkPipes))]
+	}
+
+	var start maps.Pos
+	for i, isTree := range tree {
+		if isTree {
+			start = maps.Pos{i%width - 1, i/width - 1}
+			break
+		}
+	}
+
+	var loop []maps.Pos
+	pos, cameFrom := start, -1
+	for {
+		loop = append(loop, pos)
+
+		exits := loopExits(pos)
+		grid[index(pos)] = pipes[1<<exits[0]|1<<exits[1]]
+
+		exit := exits[0]
+		if exit == cameFrom {
+			exit = exits[1]
+		}
+		pos = pos.Add(directions[exit])
+		cameFrom = (exit + 2) % len(directions)
+
+		if pos == start {
+			break
+		}
+	}
+
+	// Replace a random tile of the loop with the start, clearing any junk next to it
+	// which could look like it connects to the start
+	startPos := loop[rng.Intn(len(loop))]
+	grid[index(startPos)] = 'S'
+	for _, offset := range directions {
+		if next := startPos.Add(offset); inBounds(next) && !onLoop(next) {
+			grid[index(next)] = '.'
+		}
+	}
+
+	out := make([]byte, 0, width*(width+1))
+	for y := 0; y < width; y++ {
+		out = append(out, grid[y*width:(y+1)*width]...)
+		out = append(out, '\n')
+	}
+
+	return out
+}
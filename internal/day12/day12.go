@@ -2,6 +2,7 @@ package day12
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
@@ -12,7 +13,8 @@ import (
 )
 
 var Day12 = runner.NewStreamingDay(12, parseInput, part1, part2).
-	WithExpectedAnswers(7857, 28606137449920)
+	WithExpectedAnswers(7857, 28606137449920).
+	WithGenerator(generateRecords)
 
 func part1(_ *runner.Context, _ zerolog.Logger, input stream.Stream[Springs]) (answer int, err error) {
 	cache := make(map[string]int)
@@ -24,12 +26,11 @@ func part1(_ *runner.Context, _ zerolog.Logger, input stream.Stream[Springs]) (a
 	return stream.Sum(combinations)
 }
 
-func part2(_ *runner.Context, _ zerolog.Logger, input stream.Stream[Springs]) (answer int, err error) {
-	cache := make(map[string]int)
-
-	combinations := stream.Map(input, func(spring Springs) (int, error) {
+func part2(ctx *runner.Context, _ zerolog.Logger, input stream.Stream[Springs]) (answer int, err error) {
+	// The unfolded rows are slow to count, so count them in parallel, each with their own cache
+	combinations := stream.ParallelMapUnordered(ctx, input, 0, func(spring Springs) (int, error) {
 		spring = spring.Unfold()
-		return countOptions(cache, spring.Springs, spring.DamagedSpringGroups)
+		return countOptions(make(map[string]int), spring.Springs, spring.DamagedSpringGroups)
 	})
 
 	return stream.Sum(combinations)
@@ -222,8 +223,8 @@ func (s Springs) String() string {
 	return sb.String()
 }
 
-func parseInput(input []byte) stream.Stream[Springs] {
-	return stream.Map(stream.LinesFrom(input), func(line string) (Springs, error) {
+func parseInput(input io.Reader, options ...stream.ScannerOption) stream.Stream[Springs] {
+	return stream.Map(stream.LinesFromReader(input, options...), func(line string) (Springs, error) {
 		springs, groups, found := strings.Cut(line, " ")
 		if !found {
 			return Springs{}, errors.Newf("could not find seperator between springs and groups for %q", line)
@@ -2,6 +2,7 @@ package day12
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
@@ -29,7 +30,7 @@ func Test_Day12_Options(t *testing.T) {
 		t.Run(input, func(t *testing.T) {
 			t.Parallel()
 
-			springs, err := stream.Collect(parseInput([]byte(input)))
+			springs, err := stream.Collect(parseInput(strings.NewReader(input)))
 			assert.NoError(t, err, "Failed to parse input")
 			assert.Len(t, springs, 1, "Expected only one spring")
 
@@ -60,3 +61,7 @@ func Test_Day12_Options(t *testing.T) {
 	test("????.######..#####. 1,6,5", true, 2500)
 	test("?###???????? 3,2,1", true, 506250)
 }
+
+func Test_Generator(t *testing.T) {
+	Day12.TestGenerator(t)
+}
@@ -0,0 +1,43 @@
+package day12
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// generateRecords creates the given number of rows of springs, where each row is created with
+// every spring known and its groups calculated, before some of the springs are made unknown
+func generateRecords(rng *rand.Rand, size int) []byte {
+	var sb strings.Builder
+
+	for row := 0; row < size; row++ {
+		springs := make([]byte, 5+rng.Intn(16))
+		for i := range springs {
+			springs[i] = '.'
+			if rng.Intn(2) == 0 {
+				springs[i] = '#'
+			}
+		}
+		// There must be at least one damaged spring to give a group
+		springs[rng.Intn(len(springs))] = '#'
+
+		var groups []string
+		for _, group := range strings.FieldsFunc(string(springs), func(r rune) bool { return r == '.' }) {
+			groups = append(groups, strconv.Itoa(len(group)))
+		}
+
+		for i := range springs {
+			if rng.Intn(3) == 0 {
+				springs[i] = '?'
+			}
+		}
+
+		sb.Write(springs)
+		sb.WriteByte(' ')
+		sb.WriteString(strings.Join(groups, ","))
+		sb.WriteByte('\n')
+	}
+
+	return []byte(sb.String())
+}
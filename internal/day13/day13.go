@@ -111,45 +111,22 @@ func (p Pattern) String() string {
 	return sb.String()
 }
 
-func parsePatterns(input []byte) stream.Stream[Pattern] {
-	return &patternParser{
-		input: stream.LinesFrom(input),
-	}
-}
+func parsePatterns(input io.Reader, options ...stream.ScannerOption) stream.Stream[Pattern] {
+	// Each pattern is separated by a blank line
+	blocks := stream.SplitWhen(stream.LinesFromReader(input, options...), func(line string) (bool, error) {
+		return line == "", nil
+	})
 
-type patternParser struct {
-	input   stream.Stream[string]
-	current Pattern
+	return stream.Map(blocks, parsePattern)
 }
 
-func (p *patternParser) Next() (Pattern, error) {
-	for {
-		// Read the next line
-		line, err := p.input.Next()
-		if err != nil {
-			// If we've reached EOF on the source stream and we have a pattern
-			// return it, otherwise return the error
-			if errors.Is(err, io.EOF) && len(p.current.Rows) > 0 {
-				toReturn := p.current
-				p.current = Pattern{}
-				return toReturn, nil
-			} else {
-				return Pattern{}, err
-			}
-		}
-
-		// If we hit an empty line, return the current pattern
-		if line == "" {
-			toReturn := p.current
-			p.current = Pattern{}
-			return toReturn, nil
-		}
-
+func parsePattern(lines []string) (pattern Pattern, err error) {
+	for _, line := range lines {
 		// Sanity check the width of the line
-		if len(p.current.Cols) > 0 && len(p.current.Cols) != len(line) {
-			return Pattern{}, errors.Newf("Line %s is not the same width as previous lines in this pattern, expected width %d", line, len(p.current.Cols))
-		} else if len(p.current.Cols) == 0 {
-			p.current.Cols = make([]int32, len(line))
+		if len(pattern.Cols) > 0 && len(pattern.Cols) != len(line) {
+			return Pattern{}, errors.Newf("Line %s is not the same width as previous lines in this pattern, expected width %d", line, len(pattern.Cols))
+		} else if len(pattern.Cols) == 0 {
+			pattern.Cols = make([]int32, len(line))
 		}
 
 		row := int32(0)
@@ -163,13 +140,15 @@ func (p *patternParser) Next() (Pattern, error) {
 				row |= 1 << col
 
 				// And then on the column
-				p.current.Cols[col] |= 1 << len(p.current.Rows)
+				pattern.Cols[col] |= 1 << len(pattern.Rows)
 
 			default:
 				return Pattern{}, errors.Newf("Unknown character %c", c)
 			}
 		}
 
-		p.current.Rows = append(p.current.Rows, row)
+		pattern.Rows = append(pattern.Rows, row)
 	}
+
+	return pattern, nil
 }
@@ -12,7 +12,8 @@ import (
 
 var (
 	Day14 = runner.NewDay(14, parseFunc, part1, part2).
-		WithExpectedAnswers(105982, 85175)
+		WithExpectedAnswers(105982, 85175).
+		WithReferencePart1(part1BruteForce)
 
 	parseFunc = maps.NewParseFunc(func(r rune) (Rocks, error) {
 		switch r {
@@ -28,59 +29,45 @@ var (
 	})
 )
 
-func part1(_ *runner.Context, _ zerolog.Logger, input *maps.Map[Rocks]) (answer int, err error) {
+func part1(ctx *runner.Context, _ zerolog.Logger, input *maps.Map[Rocks]) (answer int, err error) {
 	maps.Tilt(input, maps.North, Rounded)
+	if err := ctx.GoldenText("txt", input.String()); err != nil {
+		return 0, errors.Wrap(err, "failed to save tilted map")
+	}
 
 	return load(input), nil
 }
 
-func part2(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Rocks]) (answer int, err error) {
-	const spinCount = 1_000_000_000
-	cache := make(map[string]int)
-
-	spinCycle := func(i int) {
-		// Run the spin cycle (North, West, South, East)
-		maps.Tilt(input, maps.North, Rounded)
-		maps.Tilt(input, maps.West, Rounded)
-		maps.Tilt(input, maps.South, Rounded)
-		maps.Tilt(input, maps.East, Rounded)
-		input.CaptureFrame(fmt.Sprintf("Spin Cycle: %d", i), 1)
-	}
-
-	input.StartCapturingFrames(ctx)
-
-	// Run the spin cycle 1 billion times or until we find a loop
-	var cacheKey string
-	i := 1
-	for i < spinCount {
-		spinCycle(i)
-
-		cacheKey = input.String()
-		if _, ok := cache[cacheKey]; ok {
-			break
+// part1BruteForce rolls each rock north one tile at a time until none can move,
+// for checking [maps.Tilt]
+func part1BruteForce(_ *runner.Context, _ zerolog.Logger, input *maps.Map[Rocks]) (answer int, err error) {
+	for moved := true; moved; {
+		moved = false
+
+		for idx, tile := range input.Tiles {
+			above := input.PositionOf(idx).Add(maps.Pos{0, -1})
+			if next, valid := input.Get(above); tile == Rounded && valid && next == Empty {
+				input.Set(above, Rounded)
+				input.Tiles[idx] = Empty
+				moved = true
+			}
 		}
-		cache[cacheKey] = i
-		i++
 	}
 
-	loopStart := cache[cacheKey]
-	loopLength := i - loopStart
-	if loopLength == 0 {
-		return 0, errors.New("no loop found")
-	}
-	log.Debug().Int("loop_start", loopStart).Int("loop_length", loopLength).Int("current_idx", i).Msg("found loop")
+	return load(input), nil
+}
 
-	// Fast forward to the end
-	startAt := spinCount - ((spinCount - loopStart) % loopLength)
-	log.Debug().Int("iteration", startAt).Msg("fast forwarding to end")
-	for i = startAt; i < spinCount; i++ {
-		spinCycle(i)
-	}
+// spinCount is the number of spin cycles part 2 runs
+const spinCount = 1_000_000_000
+
+func part2(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Rocks]) (answer int, err error) {
+	input.StartCapturingFrames(ctx)
 
+	spinWithLoopDetection(ctx, log, input, spinCount)
 	answer = load(input)
 
 	// Save the animation
-	input.StopCapturingFrames(fmt.Sprintf("Spin Cycle: %d - Answer: %d", i, answer))
+	input.StopCapturingFrames(fmt.Sprintf("Spin Cycle: %d - Answer: %d", spinCount, answer))
 	if err := input.SaveAnimationGIF(ctx); err != nil {
 		return 0, errors.Wrap(err, "failed to save animation gif")
 	}
@@ -88,6 +75,47 @@ func part2(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Rocks]) (ans
 	return answer, nil
 }
 
+// spinWithLoopDetection runs the given number of spin cycles on the map, skipping
+// ahead once the map returns to a state it has already been in
+func spinWithLoopDetection(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Rocks], spins int) {
+	seen := make(map[string]int)
+
+	ctx.Stage("searching for loop")
+	for i := 1; i <= spins; i++ {
+		ctx.Progress(i-1, spins)
+		spinCycle(input, i)
+
+		cacheKey := input.String()
+		loopStart, found := seen[cacheKey]
+		if !found {
+			seen[cacheKey] = i
+			continue
+		}
+
+		loopLength := i - loopStart
+		log.Debug().Int("loop_start", loopStart).Int("loop_length", loopLength).Int("current_idx", i).Msg("found loop")
+
+		// Fast forward to the end
+		startAt := spins - ((spins - loopStart) % loopLength)
+		log.Debug().Int("iteration", startAt).Msg("fast forwarding to end")
+		ctx.Stage("fast forwarding to end")
+		for i = startAt; i < spins; i++ {
+			ctx.Progress(i-startAt, spins-startAt)
+			spinCycle(input, i)
+		}
+		return
+	}
+}
+
+// spinCycle tilts the map North, West, South and then East
+func spinCycle(input *maps.Map[Rocks], i int) {
+	maps.Tilt(input, maps.North, Rounded)
+	maps.Tilt(input, maps.West, Rounded)
+	maps.Tilt(input, maps.South, Rounded)
+	maps.Tilt(input, maps.East, Rounded)
+	input.CaptureFrame(fmt.Sprintf("Spin Cycle: %d", i), 1)
+}
+
 func load(m *maps.Map[Rocks]) (sum int) {
 	for idx, tile := range m.Tiles {
 		if tile == Rounded {
@@ -1,7 +1,15 @@
 package day14
 
 import (
+	"context"
+	"strings"
 	"testing"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/maps"
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_Day14(t *testing.T) {
@@ -20,3 +28,41 @@ O.#..O.#.#
 
 	Day14.Test(t, input, 136, input, 64)
 }
+
+func Test_SpinWithLoopDetection(t *testing.T) {
+	input := `
+O....#....
+O.OO#....#
+.....##...
+OO.#O....O
+.O.....O#.
+O.#..O.#.#
+..O..#O..O
+.......O..
+#....###..
+#OO..#....
+`
+
+	ctx := &runner.Context{Context: context.Background()}
+	for spins := 0; spins <= 50; spins++ {
+		expected, err := parseFunc([]byte(strings.TrimSpace(input)))
+		require.NoError(t, err)
+		spinBruteForce(expected, spins)
+
+		actual, err := parseFunc([]byte(strings.TrimSpace(input)))
+		require.NoError(t, err)
+		spinWithLoopDetection(ctx, zerolog.Nop(), actual, spins)
+
+		assert.Equal(t, expected.String(), actual.String(), "after %d spin cycles", spins)
+	}
+}
+
+// spinBruteForce runs every one of the given number of spin cycles on the map
+//
+// Running all of part 2's spin cycles this way takes far too long to register it as a reference
+// for --verify, so it is only checked against [spinWithLoopDetection] for smaller spin counts.
+func spinBruteForce(input *maps.Map[Rocks], spins int) {
+	for i := 1; i <= spins; i++ {
+		spinCycle(input, i)
+	}
+}
@@ -1,6 +1,7 @@
 package day15
 
 import (
+	"io"
 	"strconv"
 	"strings"
 
@@ -12,8 +13,8 @@ import (
 
 var Day15 = runner.NewStreamingDay(15, parseCommas, part1, part2)
 
-func parseCommas(bytes []byte) stream.Stream[string] {
-	return stream.SplitBy(bytes, ',')
+func parseCommas(input io.Reader, options ...stream.ScannerOption) stream.Stream[string] {
+	return stream.SplitByReader(input, ',', options...)
 }
 
 func part1(_ *runner.Context, _ zerolog.Logger, input stream.Stream[string]) (answer int, err error) {
@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	Day16 = runner.NewDay(16, parseContraption, part1, part2)
+	Day16 = runner.NewDay(16, parseContraption, part1, part2).
+		WithGenerator(generateContraption)
 
 	colorPalette = []color.Color{
 		color.White,                // Empty
@@ -64,7 +65,10 @@ func part2(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Tile]) (answ
 	bestY := 0
 	bestDir := LaserRight
 
+	ctx.Stage("top and bottom edges")
 	for x := 0; x < input.Width; x++ {
+		ctx.Progress(x, input.Width)
+
 		thisOption, err := runLasers(input, x, 0, LaserDown, false)
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to run lasers")
@@ -92,7 +96,10 @@ func part2(ctx *runner.Context, log zerolog.Logger, input *maps.Map[Tile]) (answ
 		}
 	}
 
+	ctx.Stage("left and right edges")
 	for y := 0; y < input.Height; y++ {
+		ctx.Progress(y, input.Height)
+
 		thisOption, err := runLasers(input, 0, y, LaserRight, false)
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to run lasers")
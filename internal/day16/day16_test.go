@@ -19,3 +19,7 @@ func Test_Day16(t *testing.T) {
 `
 	Day16.Test(t, input, 46, input, 51)
 }
+
+func Test_Generator(t *testing.T) {
+	Day16.TestGenerator(t)
+}
@@ -0,0 +1,28 @@
+package day16
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// mirrors are the mirrors and splitters which can appear in the contraption
+const mirrors = `/\|-`
+
+// generateContraption creates a square contraption of the given width, with roughly
+// one in ten tiles being a mirror or splitter
+func generateContraption(rng *rand.Rand, size int) []byte {
+	var buf bytes.Buffer
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if rng.Intn(10) == 0 {
+				buf.WriteByte(mirrors[rng.Intn(len(mirrors))])
+			} else {
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
@@ -14,7 +14,8 @@ import (
 
 var (
 	Day17 = runner.NewDay(17, parseFunc, part1, part2).
-		WithExpectedAnswers(907, 1057)
+		WithExpectedAnswers(907, 1057).
+		WithGenerator(generateHeatMap)
 
 	// colorPalette starts with a faint red and goes to a deep red in 9 steps
 	colorPalette = []color.Color{
@@ -23,3 +23,7 @@ func Test_Day17(t *testing.T) {
 
 	Day17.Test(t, input, 102, input, 94)
 }
+
+func Test_Generator(t *testing.T) {
+	Day17.TestGenerator(t)
+}
@@ -0,0 +1,25 @@
+package day17
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// minMapSize is the smallest map the ultra crucible, which must move at least four blocks
+// before it can stop, can cross
+const minMapSize = 5
+
+// generateHeatMap creates a square map of the given width, with each block losing between 1 and 9 heat
+func generateHeatMap(rng *rand.Rand, size int) []byte {
+	var buf bytes.Buffer
+	size = max(size, minMapSize)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			buf.WriteByte(byte('1' + rng.Intn(9)))
+		}
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
@@ -74,6 +74,10 @@ func part2(ctx *runner.Context, log zerolog.Logger, input []Instruction) (answer
 
 func parseInstructions(input []byte) ([]Instruction, error) {
 	return stream.Collect(stream.Map(stream.LinesFrom(input), func(line string) (rtn Instruction, err error) {
+		if len(line) < 2 {
+			return rtn, errors.Newf("invalid line: %q", line)
+		}
+
 		switch line[0] {
 		// Parse the direction
 		case 'R':
@@ -119,7 +123,7 @@ func parseInstructions(input []byte) ([]Instruction, error) {
 		case '3':
 			rtn.SwappedDirection = maps.Pos{0, 1}
 		default:
-			return rtn, errors.Newf("invalid swapped direction: %c", hexCode[7])
+			return rtn, errors.Newf("invalid swapped direction: %c", hexCode[5])
 		}
 
 		// The remaining 6 digits encode the swapped length
@@ -1,25 +1,33 @@
 package day18
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/maps"
 )
 
 func Test_Day18(t *testing.T) {
-	input := `
-R 6 (#70c710)
-D 5 (#0dc571)
-L 2 (#5713f0)
-D 2 (#d2c081)
-R 2 (#59c680)
-D 2 (#411b91)
-L 5 (#8ceee2)
-U 2 (#caa173)
-L 1 (#1b58a2)
-U 2 (#caa171)
-R 2 (#7807d2)
-U 3 (#a77fa3)
-L 2 (#015232)
-U 2 (#7a21e3)
-`
-	Day18.Test(t, input, 62, input, 952408144115)
+	Day18.TestExamples(t)
+}
+
+func FuzzParser(f *testing.F) {
+	Day18.FuzzParser(f, formatInstructions, "R 6 (#70c710)\n\nD 5 (#0dc571)", "U", "L 1 (#")
+}
+
+// formatInstructions prints the instructions in the puzzle format
+func formatInstructions(instructions []Instruction) string {
+	directions := map[maps.Pos]string{{1, 0}: "R", {0, 1}: "D", {-1, 0}: "L", {0, -1}: "U"}
+	swappedDirections := map[maps.Pos]int{{1, 0}: 0, {0, -1}: 1, {-1, 0}: 2, {0, 1}: 3}
+
+	lines := make([]string, len(instructions))
+	for i, instruction := range instructions {
+		lines[i] = fmt.Sprintf("%s %d (#%05x%d)",
+			directions[instruction.Direction], instruction.Length,
+			instruction.SwappedLength, swappedDirections[instruction.SwappedDirection],
+		)
+	}
+
+	return strings.Join(lines, "\n")
 }
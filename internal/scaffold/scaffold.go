@@ -0,0 +1,159 @@
+// Package scaffold generates the packages for new days, and the list of imports
+// which registers every day with the runner.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"text/template"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
+	"github.com/cockroachdb/errors"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// Day describes the package to generate for a day
+type Day struct {
+	Year      int
+	Day       int
+	Grid      bool // Parse the input as a [maps.Map] of tiles
+	Streaming bool // Parse the input as a stream rather than all at once
+}
+
+// DefaultYear is the year of days which don't need to call [runner.Day.WithYear]
+func (d Day) DefaultYear() int { return runner.DefaultYear }
+
+// yearDir returns the directory within base for the days year, which is base
+// itself for the [runner.DefaultYear]
+func (d Day) yearDir(base string) string {
+	if d.Year == runner.DefaultYear {
+		return base
+	}
+
+	return filepath.Join(base, strconv.Itoa(d.Year))
+}
+
+// Package is the name of the generated package
+func (d Day) Package() string { return fmt.Sprintf("day%02d", d.Day) }
+
+// Var is the name of the exported variable holding the [runner.Day]
+func (d Day) Var() string { return fmt.Sprintf("Day%02d", d.Day) }
+
+// InputType is the type of input given to each part
+func (d Day) InputType() string {
+	switch {
+	case d.Grid && d.Streaming:
+		return "stream.Stream[*maps.Map[Tile]]"
+	case d.Grid:
+		return "*maps.Map[Tile]"
+	case d.Streaming:
+		return "stream.Stream[Line]"
+	default:
+		return "Input"
+	}
+}
+
+// Generate writes the day package, its test, a placeholder example and an empty input file, then
+// regenerates the list of registered days
+func (d Day) Generate(repoDir string) error {
+	pkgDir := filepath.Join(d.yearDir(filepath.Join(repoDir, "internal")), d.Package())
+	if _, err := os.Stat(pkgDir); err == nil {
+		return errors.Newf("%s already exists", pkgDir)
+	}
+
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create package directory")
+	}
+
+	if err := writeTemplate(filepath.Join(pkgDir, d.Package()+".go"), "day.go.tmpl", d); err != nil {
+		return err
+	}
+	if err := writeTemplate(filepath.Join(pkgDir, d.Package()+"_test.go"), "day_test.go.tmpl", d); err != nil {
+		return err
+	}
+
+	examplesDir := filepath.Join(pkgDir, "testdata", "examples")
+	if err := os.MkdirAll(examplesDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create examples directory")
+	}
+	if err := writeTemplate(filepath.Join(examplesDir, "example.txt"), "example.txt.tmpl", d); err != nil {
+		return err
+	}
+
+	inputDir := d.yearDir(filepath.Join(repoDir, "inputs"))
+	if err := os.MkdirAll(inputDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create inputs directory")
+	}
+
+	inputFile := filepath.Join(inputDir, d.Package()+".txt")
+	if _, err := os.Stat(inputFile); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(inputFile, nil, 0644); err != nil {
+			return errors.Wrap(err, "failed to create input file")
+		}
+	}
+
+	return WriteDaysFile(repoDir)
+}
+
+// WriteDaysFile regenerates cmd/aoc2023/days.go, which imports every day package
+// in internal so that they register themselves with the runner
+//
+// Days in the [runner.DefaultYear] are found at internal/dayNN, and other years at internal/YYYY/dayNN.
+func WriteDaysFile(repoDir string) error {
+	internalDir := filepath.Join(repoDir, "internal")
+
+	var packages []string
+	for _, pattern := range []string{"day[0-9][0-9]", filepath.Join("[0-9][0-9][0-9][0-9]", "day[0-9][0-9]")} {
+		matches, err := filepath.Glob(filepath.Join(internalDir, pattern))
+		if err != nil {
+			return errors.Wrap(err, "failed to list day packages")
+		}
+
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+
+			rel, err := filepath.Rel(internalDir, match)
+			if err != nil {
+				return errors.Wrap(err, "failed to find day package")
+			}
+			packages = append(packages, filepath.ToSlash(rel))
+		}
+	}
+	slices.Sort(packages)
+
+	return writeTemplate(filepath.Join(repoDir, "cmd", "aoc2023", "days.go"), "days.go.tmpl", packages)
+}
+
+// writeTemplate executes the named template and writes the formatted result to path
+func writeTemplate(path string, name string, data any) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return errors.Wrapf(err, "failed to execute template %s", name)
+	}
+
+	src := buf.Bytes()
+	if filepath.Ext(path) == ".go" {
+		var err error
+		if src, err = format.Source(src); err != nil {
+			return errors.Wrapf(err, "failed to format %s", path)
+		}
+	}
+
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+
+	return nil
+}
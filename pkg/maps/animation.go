@@ -4,7 +4,6 @@ import (
 	"image"
 	"image/color"
 	"image/gif"
-	"os"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/runner"
 	"github.com/cockroachdb/errors"
@@ -64,6 +63,8 @@ func (m *Map[TileType]) CaptureFrame(label string, delay int) {
 	})
 }
 
+// SaveAnimationGIF renders the captured frames as a GIF and saves it using [runner.Context.GoldenGIF],
+// so when testing it is checked against the golden GIF in the days testdata directory
 func (m *Map[TileType]) SaveAnimationGIF(ctx *runner.Context) error {
 	if !ctx.SaveOutput() {
 		return nil
@@ -78,13 +79,6 @@ func (m *Map[TileType]) SaveAnimationGIF(ctx *runner.Context) error {
 		m.StopCapturingFrames("")
 	}
 
-	// Create the output file
-	f, err := os.OpenFile(ctx.OutputFile("gif"), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
-	if err != nil {
-		return errors.Wrap(err, "failed to create output file")
-	}
-	defer func() { _ = f.Close() }()
-
 	fontToUse := basicfont.Face7x13
 	fontDraw := &font.Drawer{
 		Src:  image.NewUniform(color.Black),
@@ -179,9 +173,9 @@ func (m *Map[TileType]) SaveAnimationGIF(ctx *runner.Context) error {
 		cfg.Delay = append(cfg.Delay, frame.delay)
 	}
 
-	// Save the GIF
-	if err := gif.EncodeAll(f, cfg); err != nil {
-		return errors.Wrap(err, "failed to encode gif")
+	// Save the GIF, or when testing check it against the golden file
+	if err := ctx.GoldenGIF("gif", cfg); err != nil {
+		return err
 	}
 
 	// Clear the frames
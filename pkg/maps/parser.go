@@ -1,6 +1,7 @@
 package maps
 
 import (
+	"bytes"
 	"io"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
@@ -21,7 +22,7 @@ type streamParser[TileType Tile] struct {
 // then use [NewStreamingParseFunc].
 func NewParseFunc[TileType Tile](parseTile func(r rune) (TileType, error), options ...MapOption) func([]byte) (*Map[TileType], error) {
 	return func(data []byte) (*Map[TileType], error) {
-		streamingParser := NewStreamingParseFunc(parseTile, options...)(data)
+		streamingParser := NewStreamingParseFunc(parseTile, options...)(bytes.NewReader(data), stream.WithWholeInputBuffer(data))
 		maps, err := stream.Collect(streamingParser)
 		if err != nil {
 			return nil, err
@@ -36,13 +37,13 @@ func NewParseFunc[TileType Tile](parseTile func(r rune) (TileType, error), optio
 }
 
 // NewStreamingParseFunc creates a new stream parser that will parse one or more maps from an original
-// byte slice.
+// input, reading it as the stream is read. The scanner options configure how the lines are read.
 //
 // If you only need to parse a single map use [NewParseFunc].
-func NewStreamingParseFunc[TileType Tile](parseTile func(r rune) (TileType, error), options ...MapOption) func([]byte) stream.Stream[*Map[TileType]] {
-	return func(data []byte) stream.Stream[*Map[TileType]] {
+func NewStreamingParseFunc[TileType Tile](parseTile func(r rune) (TileType, error), options ...MapOption) func(io.Reader, ...stream.ScannerOption) stream.Stream[*Map[TileType]] {
+	return func(input io.Reader, scannerOptions ...stream.ScannerOption) stream.Stream[*Map[TileType]] {
 		return &streamParser[TileType]{
-			input:     stream.LinesFrom(data),
+			input:     stream.LinesFromReader(input, scannerOptions...),
 			options:   options,
 			parseTile: parseTile,
 		}
@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+// AnswerType is the set of types which a [Part] can return as its answer
+type AnswerType interface {
+	int | int64 | string | *big.Int
+}
+
+// Answer is an answer returned from a part, normalised so that answers of
+// any [AnswerType] can be compared, logged and stored in the same way.
+//
+// The zero value is an empty string answer.
+type Answer struct {
+	num *big.Int // set if this is a numeric answer
+	str string   // the answer if it is not numeric
+}
+
+// AnswerOf converts the given value into an [Answer]
+func AnswerOf[A AnswerType](value A) Answer {
+	switch v := any(value).(type) {
+	case int:
+		return Answer{num: big.NewInt(int64(v))}
+	case int64:
+		return Answer{num: big.NewInt(v)}
+	case *big.Int:
+		if v == nil {
+			return Answer{num: new(big.Int)}
+		}
+		return Answer{num: new(big.Int).Set(v)}
+	case string:
+		return Answer{str: v}
+	default:
+		panic("unreachable: unknown answer type")
+	}
+}
+
+// ParseAnswer converts the text into an [Answer], which is numeric if the text is an integer
+func ParseAnswer(s string) Answer {
+	if num, ok := new(big.Int).SetString(s, 10); ok {
+		return Answer{num: num}
+	}
+
+	return Answer{str: s}
+}
+
+// IsNumeric returns true if the answer is a number
+func (a Answer) IsNumeric() bool {
+	return a.num != nil
+}
+
+// String returns the answer as a string
+func (a Answer) String() string {
+	if a.num != nil {
+		return a.num.String()
+	}
+
+	return a.str
+}
+
+// Equal returns true if both answers are the same
+//
+// When a string answer is compared with a numeric one, the string is first parsed with
+// [ParseAnswer], so a string answer of "0123" is equal to a numeric answer of 123.
+func (a Answer) Equal(b Answer) bool {
+	if a.IsNumeric() != b.IsNumeric() {
+		if a.IsNumeric() {
+			b = ParseAnswer(b.str)
+		} else {
+			a = ParseAnswer(a.str)
+		}
+	}
+
+	if a.num != nil && b.num != nil {
+		return a.num.Cmp(b.num) == 0
+	}
+
+	return a.str == b.str
+}
+
+// Cmp compares two numeric answers, returning -1 if a < b, 0 if a == b and +1 if a > b
+//
+// ok will be false if either answer is not numeric.
+func (a Answer) Cmp(b Answer) (cmp int, ok bool) {
+	if a.num == nil || b.num == nil {
+		return 0, false
+	}
+
+	return a.num.Cmp(b.num), true
+}
+
+// MarshalJSON encodes numeric answers as JSON numbers and all others as strings
+func (a Answer) MarshalJSON() ([]byte, error) {
+	if a.num != nil {
+		return []byte(a.num.String()), nil
+	}
+
+	return json.Marshal(a.str)
+}
+
+// UnmarshalJSON decodes an answer from either a JSON number or string
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	var raw any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return errors.Wrap(err, "failed to decode answer")
+	}
+
+	switch v := raw.(type) {
+	case json.Number:
+		num, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return errors.Newf("answer %s is not an integer", v)
+		}
+		*a = Answer{num: num}
+	case string:
+		*a = Answer{str: v}
+	default:
+		return errors.Newf("answer must be a number or string, got %s", strconv.Quote(string(data)))
+	}
+
+	return nil
+}
+
+// ptr returns a pointer to a copy of the given value
+func ptr[V any](v V) *V {
+	return &v
+}
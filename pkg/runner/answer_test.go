@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnswer_Equal(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	assert.True(t, AnswerOf(42).Equal(AnswerOf(int64(42))), "int and int64")
+	assert.True(t, AnswerOf(42).Equal(AnswerOf(big.NewInt(42))), "int and big.Int")
+	assert.True(t, AnswerOf("42").Equal(AnswerOf(42)), "string and int")
+	assert.True(t, AnswerOf("0123").Equal(AnswerOf(123)), "string with a leading zero and int")
+	assert.True(t, AnswerOf(123).Equal(AnswerOf("+123")), "int and string with a sign")
+	assert.False(t, AnswerOf("0123").Equal(AnswerOf("123")), "strings are compared as written")
+	assert.False(t, AnswerOf("12x").Equal(AnswerOf(12)), "non-numeric string and int")
+	assert.True(t, AnswerOf(huge).Equal(AnswerOf("123456789012345678901234567890")), "big.Int and string")
+	assert.False(t, AnswerOf(42).Equal(AnswerOf(43)), "different ints")
+	assert.False(t, AnswerOf("ABC").Equal(AnswerOf("abc")), "different strings")
+}
+
+func TestAnswer_Cmp(t *testing.T) {
+	cmp, ok := AnswerOf(10).Cmp(AnswerOf(big.NewInt(20)))
+	assert.True(t, ok)
+	assert.Equal(t, -1, cmp)
+
+	_, ok = AnswerOf("10").Cmp(AnswerOf(20))
+	assert.False(t, ok, "strings are not comparable")
+}
+
+func TestAnswer_JSON(t *testing.T) {
+	tests := []struct {
+		answer Answer
+		json   string
+	}{
+		{AnswerOf(42), `42`},
+		{AnswerOf(int64(-7)), `-7`},
+		{AnswerOf("RFKZCPEF"), `"RFKZCPEF"`},
+		{AnswerOf(new(big.Int).Lsh(big.NewInt(1), 100)), `1267650600228229401496703205376`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.answer)
+		assert.NoError(t, err)
+		assert.Equal(t, test.json, string(data))
+
+		var decoded Answer
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.True(t, test.answer.Equal(decoded), "round trip of %s", test.json)
+		assert.Equal(t, test.answer.IsNumeric(), decoded.IsNumeric(), "round trip of %s", test.json)
+	}
+
+	var decoded Answer
+	assert.Error(t, json.Unmarshal([]byte(`1.5`), &decoded), "non-integer numbers")
+	assert.Error(t, json.Unmarshal([]byte(`true`), &decoded), "booleans")
+}
+
+func TestParseAnswer(t *testing.T) {
+	assert.Equal(t, AnswerOf(int64(42)).String(), ParseAnswer("42").String())
+	assert.True(t, ParseAnswer("1267650600228229401496703205376").Equal(AnswerOf(new(big.Int).Lsh(big.NewInt(1), 100))))
+	assert.Equal(t, AnswerOf("RFKZCPEF"), ParseAnswer("RFKZCPEF"))
+}
@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// AnswersFile is a set of expected answers which are stored outside the code
+//
+// On disk it is a JSON document keyed by day ("day01", or "2022/day01" for days
+// outside the [DefaultYear]), then by input name, then by part ("part1" or "part2"):
+//
+//	{
+//	  "day08": {
+//	    "default": { "part1": 16897, "part2": 16563603485021 }
+//	  }
+//	}
+//
+// Answers in the file are merged with those registered in code using
+// [Day.WithExpectedAnswers], with the answers in code taking priority.
+type AnswersFile struct {
+	mu      sync.Mutex
+	answers map[DayID]map[string]*fileAnswers // day -> input -> answers
+}
+
+// fileAnswers are the answers for a single day and input in an [AnswersFile]
+type fileAnswers struct {
+	Part1 *Answer `json:"part1,omitempty"`
+	Part2 *Answer `json:"part2,omitempty"`
+}
+
+// DefaultAnswersFile returns the path of the answers file in the root of the repo
+func DefaultAnswersFile() string {
+	return filepath.Join(repoDir, "answers.json")
+}
+
+// LoadAnswersFile reads the answers file at the given path
+//
+// If the file does not exist, an empty set of answers is returned.
+func LoadAnswersFile(path string) (*AnswersFile, error) {
+	f := &AnswersFile{answers: make(map[DayID]map[string]*fileAnswers)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read answers file")
+	}
+
+	var raw map[string]map[string]*fileAnswers
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse answers file %s", path)
+	}
+
+	for key, inputs := range raw {
+		day, err := ParseDayID(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid day in answers file %s", path)
+		}
+
+		f.answers[day] = inputs
+	}
+
+	return f, nil
+}
+
+// Save writes the answers to the given path
+func (f *AnswersFile) Save(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	raw := make(map[string]map[string]*fileAnswers, len(f.answers))
+	for day, inputs := range f.answers {
+		raw[day.String()] = inputs
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode answers file")
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "failed to write answers file")
+	}
+
+	return nil
+}
+
+// Record stores the answers of every part which passed all its checks
+//
+// It returns a description of each answer which was added or changed.
+func (f *AnswersFile) Record(results []DayResult) (changes []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, result := range results {
+		for _, part := range result.Parts {
+			if part.Status != StatusOK {
+				continue
+			}
+
+			inputs, found := f.answers[result.ID()]
+			if !found {
+				inputs = make(map[string]*fileAnswers)
+				f.answers[result.ID()] = inputs
+			}
+
+			answers, found := inputs[result.Input]
+			if !found {
+				answers = &fileAnswers{}
+				inputs[result.Input] = answers
+			}
+
+			stored := &answers.Part1
+			if part.Part == 2 {
+				stored = &answers.Part2
+			}
+
+			if *stored == nil || !(*stored).Equal(part.Answer) {
+				*stored = ptr(part.Answer)
+				changes = append(changes, fmt.Sprintf("%s part %d (%s) = %s", result.ID(), part.Part, result.Input, part.Answer))
+			}
+		}
+	}
+
+	return changes
+}
+
+// lookup returns the answers stored for the given day and input, if any
+func (f *AnswersFile) lookup(day DayID, input string) (part1, part2 *Answer) {
+	if f == nil {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if answers, found := f.answers[day][input]; found {
+		return answers.Part1, answers.Part2
+	}
+
+	return nil, nil
+}
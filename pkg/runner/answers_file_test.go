@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswersFile_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "day01": {
+    "default": { "part1": 142, "part2": 281 }
+  }
+}
+`), 0644))
+
+	f, err := LoadAnswersFile(path)
+	require.NoError(t, err)
+
+	changes := f.Record([]DayResult{
+		{
+			Year:  DefaultYear,
+			Day:   1,
+			Input: DefaultInput,
+			Parts: []PartResult{
+				{Part: 1, Status: StatusOK, Answer: AnswerOf(142)}, // Unchanged
+				{Part: 2, Status: StatusOK, Answer: AnswerOf(280)}, // Changed
+			},
+		},
+		{
+			Year:  DefaultYear,
+			Day:   2,
+			Input: "alt",
+			Parts: []PartResult{
+				{Part: 1, Status: StatusOK, Answer: AnswerOf("ABC")}, // New
+				{Part: 2, Status: StatusWrong, Answer: AnswerOf(99)}, // Failed, so not recorded
+			},
+		},
+		{
+			Year:  2022,
+			Day:   3,
+			Input: DefaultInput,
+			Parts: []PartResult{
+				{Part: 2, Status: StatusOK, Answer: AnswerOf(7)}, // New, outside the default year
+			},
+		},
+	})
+
+	assert.Equal(t, []string{
+		"day01 part 2 (default) = 280",
+		"day02 part 1 (alt) = ABC",
+		"2022/day03 part 2 (default) = 7",
+	}, changes)
+
+	part1, part2 := f.lookup(DayID{Year: DefaultYear, Day: 1}, DefaultInput)
+	assert.Equal(t, ptr(AnswerOf(142)), part1)
+	assert.Equal(t, ptr(AnswerOf(280)), part2)
+
+	part1, part2 = f.lookup(DayID{Year: DefaultYear, Day: 2}, "alt")
+	assert.Equal(t, ptr(AnswerOf("ABC")), part1)
+	assert.Nil(t, part2, "failed parts should not be recorded")
+
+	// Recording the same results again changes nothing
+	assert.Empty(t, f.Record([]DayResult{{
+		Year:  DefaultYear,
+		Day:   1,
+		Input: DefaultInput,
+		Parts: []PartResult{{Part: 2, Status: StatusOK, Answer: AnswerOf(280)}},
+	}}))
+}
+
+func TestAnswersFile_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	f, err := LoadAnswersFile(path)
+	require.NoError(t, err, "a missing file should load as empty")
+
+	f.Record([]DayResult{
+		{
+			Year:  DefaultYear,
+			Day:   8,
+			Input: DefaultInput,
+			Parts: []PartResult{
+				{Part: 1, Status: StatusOK, Answer: AnswerOf(16897)},
+				{Part: 2, Status: StatusOK, Answer: AnswerOf(int64(16563603485021))},
+			},
+		},
+		{
+			Year:  2022,
+			Day:   10,
+			Input: DefaultInput,
+			Parts: []PartResult{{Part: 2, Status: StatusOK, Answer: AnswerOf("RFKZCPEF")}},
+		},
+	})
+	require.NoError(t, f.Save(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"day08": {"default": {"part1": 16897, "part2": 16563603485021}},
+		"2022/day10": {"default": {"part2": "RFKZCPEF"}}
+	}`, string(data))
+
+	loaded, err := LoadAnswersFile(path)
+	require.NoError(t, err)
+
+	part1, part2 := loaded.lookup(DayID{Year: DefaultYear, Day: 8}, DefaultInput)
+	assert.Equal(t, ptr(AnswerOf(16897)), part1)
+	assert.Equal(t, ptr(AnswerOf(int64(16563603485021))), part2)
+
+	part1, part2 = loaded.lookup(DayID{Year: 2022, Day: 10}, DefaultInput)
+	assert.Nil(t, part1)
+	assert.Equal(t, ptr(AnswerOf("RFKZCPEF")), part2)
+}
+
+func TestDay_KnownAnswers_AnswersFile(t *testing.T) {
+	f := &AnswersFile{answers: map[DayID]map[string]*fileAnswers{
+		{Year: 1999, Day: 1}: {
+			DefaultInput: {Part1: ptr(AnswerOf(1)), Part2: ptr(AnswerOf(2))},
+		},
+	}}
+
+	d := &Day[int, int, int, int]{year: 1999, day: 1}
+	known := d.knownAnswers(DefaultInput, f, nil)
+	assert.Equal(t, ptr(AnswerOf(1)), known.part1.answer)
+	assert.Equal(t, ptr(AnswerOf(2)), known.part2.answer)
+
+	// Answers registered in code take priority over the file
+	d.answers = map[string]*inputAnswers{
+		DefaultInput: {part1: answers{answer: ptr(AnswerOf(10))}},
+	}
+	known = d.knownAnswers(DefaultInput, f, nil)
+	assert.Equal(t, ptr(AnswerOf(10)), known.part1.answer)
+	assert.Equal(t, ptr(AnswerOf(2)), known.part2.answer)
+
+	known = d.knownAnswers("other", f, nil)
+	assert.Nil(t, known.part1.answer)
+	assert.Nil(t, known.part2.answer)
+}
@@ -0,0 +1,222 @@
+package runner
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// BenchOptions controls how many times each stage is run when benchmarking
+type BenchOptions struct {
+	InputOptions               // Where to read the inputs from
+	MaxRuns      int           // The maximum number of times to run each stage
+	TargetDur    time.Duration // Stop running a stage once this much time has been spent on it
+}
+
+// BenchStats are the statistics gathered from repeatedly running a single stage
+type BenchStats struct {
+	Runs        int           // The number of times the stage was run
+	Min         time.Duration // The fastest run
+	Median      time.Duration // The median run
+	P95         time.Duration // The 95th percentile run
+	Max         time.Duration // The slowest run
+	AllocsPerOp uint64        // The average number of heap allocations per run
+	BytesPerOp  uint64        // The average number of bytes allocated per run
+}
+
+// PartBenchResult is the result of benchmarking a single part of a day
+type PartBenchResult struct {
+	Part           int        // The part number
+	NotImplemented bool       // True if the part has not been implemented
+	Stats          BenchStats // The timing statistics for the part
+	Err            error      // Set if the part returned an error while benchmarking
+}
+
+// BenchResult is the result of benchmarking a day against a single input
+type BenchResult struct {
+	Year  int               // The year of the event
+	Day   int               // The day number
+	Input string            // The name of the input which was used
+	Parse BenchStats        // The timing statistics for parsing the input
+	Parts []PartBenchResult // The results of each part
+	Err   error             // Set if the input could not be read or parsed
+}
+
+// ID returns the ID of the day the result is for
+func (r BenchResult) ID() DayID {
+	return DayID{Year: r.Year, Day: r.Day}
+}
+
+// Bench repeatedly runs the parser and each part of the day, gathering timing
+// and allocation statistics for each.
+//
+// The parts are given the cached parsed input in the same way as [Day.Run],
+// and each of the days inputs is benchmarked separately.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Bench(ctx context.Context, opts BenchOptions) []BenchResult {
+	inputs, err := opts.readInputs(d.id())
+	if err != nil {
+		result := BenchResult{Year: d.year, Day: d.day, Err: err}
+		logBenchResult(ctx, result)
+		return []BenchResult{result}
+	}
+
+	results := make([]BenchResult, 0, len(inputs))
+	for _, input := range inputs {
+		result := d.benchInput(ctx, opts, input)
+		logBenchResult(ctx, result)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// benchInput benchmarks both parts against a single input
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) benchInput(ctx context.Context, opts BenchOptions, input namedInput) (result BenchResult) {
+	result.Year = d.year
+	result.Day = d.day
+	result.Input = input.name
+
+	var cacheData Cache
+	var err error
+	result.Parse, err = benchmark(ctx, opts, func() (err error) {
+		cacheData, err = d.inputPreprocessor(input.data)
+		return err
+	})
+	if err != nil {
+		result.Err = errors.Wrap(err, "failed to preprocess input")
+		return result
+	}
+
+	// Parts shouldn't be logging during a benchmark
+	nopLogger := zerolog.Nop()
+
+	benchPart := func(partNum int, fn anyPart[Input]) (result PartBenchResult) {
+		result.Part = partNum
+
+		if fn == nil {
+			result.NotImplemented = true
+			return result
+		}
+
+		partCtx := &Context{
+			Context: ctx,
+			log:     nopLogger,
+			year:    d.year,
+			day:     d.day,
+			part:    partNum,
+		}
+
+		result.Stats, result.Err = benchmark(ctx, opts, func() error {
+			_, err := fn(partCtx, nopLogger, d.cacheToInput(cacheData))
+			return err
+		})
+		if errors.Is(result.Err, ErrNotImplemented) {
+			result.NotImplemented = true
+			result.Err = nil
+		}
+
+		return result
+	}
+
+	result.Parts = []PartBenchResult{
+		benchPart(1, d.part1),
+		benchPart(2, d.part2),
+	}
+
+	return result
+}
+
+// benchmark runs fn repeatedly until either opts.MaxRuns is reached or
+// opts.TargetDur has elapsed, returning statistics about the runs.
+//
+// fn is always run at least once.
+func benchmark(ctx context.Context, opts BenchOptions, fn func() error) (stats BenchStats, err error) {
+	maxRuns := max(opts.MaxRuns, 1)
+	durations := make([]time.Duration, 0, maxRuns)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for len(durations) < maxRuns {
+		runStart := time.Now()
+		if err := fn(); err != nil {
+			return stats, err
+		}
+		durations = append(durations, time.Since(runStart))
+
+		if time.Since(start) >= opts.TargetDur || ctx.Err() != nil {
+			break
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	slices.Sort(durations)
+	stats.Runs = len(durations)
+	stats.Min = durations[0]
+	stats.Median = percentile(durations, 0.5)
+	stats.P95 = percentile(durations, 0.95)
+	stats.Max = durations[len(durations)-1]
+	stats.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(stats.Runs)
+	stats.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(stats.Runs)
+
+	return stats, nil
+}
+
+// percentile returns the p'th percentile (0 to 1) from the sorted durations
+// using the nearest-rank method
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// logBenchResult writes the benchmark result to the logger attached to ctx
+func logBenchResult(ctx context.Context, result BenchResult) {
+	logCtx := log.Ctx(ctx).With()
+	if result.Year != DefaultYear {
+		logCtx = logCtx.Int("_year", result.Year)
+	}
+	logger := logCtx.Int("_day", result.Day).Logger()
+	if result.Input != "" && result.Input != DefaultInput {
+		logger = logger.With().Str("_input", result.Input).Logger()
+	}
+
+	if result.Err != nil {
+		logger.Err(result.Err).Msg("failed to benchmark day")
+		return
+	}
+
+	logStats := func(event *zerolog.Event, stats BenchStats) *zerolog.Event {
+		return event.
+			Int("runs", stats.Runs).
+			Str("min", stats.Min.String()).
+			Str("median", stats.Median.String()).
+			Str("p95", stats.P95.String()).
+			Str("max", stats.Max.String()).
+			Uint64("allocs/op", stats.AllocsPerOp).
+			Uint64("bytes/op", stats.BytesPerOp)
+	}
+
+	logStats(logger.Info(), result.Parse).Msg("input parsing benchmarked")
+
+	for _, part := range result.Parts {
+		logger := logger.With().Int("_part", part.Part).Logger()
+
+		switch {
+		case part.NotImplemented:
+			logger.Warn().Msg("part not implemented")
+		case part.Err != nil:
+			logger.Err(part.Err).Msg("failed to benchmark part")
+		default:
+			logStats(logger.Info(), part.Stats).Msg("part benchmarked")
+		}
+	}
+}
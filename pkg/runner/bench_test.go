@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDay_Bench_NotImplemented(t *testing.T) {
+	d := newTestDay(
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n * n, nil
+		},
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return 0, ErrNotImplemented
+		},
+	)
+
+	result := d.benchInput(context.Background(), BenchOptions{MaxRuns: 3, TargetDur: time.Minute}, namedInput{name: DefaultInput, data: []byte("7")})
+	require.NoError(t, result.Err)
+	require.Len(t, result.Parts, 2)
+
+	assert.False(t, result.Parts[0].NotImplemented)
+	assert.NoError(t, result.Parts[0].Err)
+	assert.Equal(t, 3, result.Parts[0].Stats.Runs)
+
+	assert.True(t, result.Parts[1].NotImplemented, "part returning ErrNotImplemented should be reported as not implemented")
+	assert.NoError(t, result.Parts[1].Err)
+}
@@ -13,23 +13,35 @@ import (
 
 // Context is the runner
 type Context struct {
-	context.Context                // base context
-	log             zerolog.Logger // logger
-	day             int            // day number
-	part            int            // part number
-	test            *testing.T     // If running as a test, this is the test
-	saveOutput      bool           // record output to file
-	isTest          bool           // is this run part of a test
+	context.Context                   // base context
+	log             zerolog.Logger    // logger
+	year            int               // year of the event
+	day             int               // day number
+	part            int               // part number
+	test            *testing.T        // If running as a test, this is the test
+	saveOutput      bool              // record output to file
+	isTest          bool              // is this run part of a test
+	example         string            // If running an example from testdata, this is its name
+	progress        *progressReporter // reports the parts progress, nil if progress is not reported
 }
 
 func (c *Context) Ctx() context.Context {
 	return c.Context
 }
 
+func (c *Context) Year() int {
+	return c.year
+}
+
 func (c *Context) Day() int {
 	return c.day
 }
 
+// id returns the ID of the day being run
+func (c *Context) id() DayID {
+	return DayID{Year: c.year, Day: c.day}
+}
+
 // SaveOutput returns true if the output should be saved to a file
 func (c *Context) SaveOutput() bool {
 	if c == nil {
@@ -40,6 +52,8 @@ func (c *Context) SaveOutput() bool {
 }
 
 // OutputFile returns the path to the output file for this day and part
+//
+// Outputs for days outside the [DefaultYear] are written into a subdirectory named after the year.
 func (c *Context) OutputFile(ext string) string {
 	if c == nil {
 		return ""
@@ -47,9 +61,9 @@ func (c *Context) OutputFile(ext string) string {
 
 	var dir string
 	if c.isTest {
-		dir = filepath.Join(repoDir, "internal", fmt.Sprintf("day%02d", c.day), "testdata")
+		dir = filepath.Join(c.id().PackageDir(), "testdata")
 	} else {
-		dir = filepath.Join(repoDir, "outputs")
+		dir = c.id().dir(filepath.Join(repoDir, "outputs"))
 	}
 
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -59,6 +73,8 @@ func (c *Context) OutputFile(ext string) string {
 	if c.isTest {
 		if c.test != nil {
 			return filepath.Join(dir, strings.ToLower(fmt.Sprintf("part%02d_%s.%s", c.part, filepath.Base(c.test.Name()), ext)))
+		} else if c.example != "" {
+			return filepath.Join(dir, fmt.Sprintf("part%02d_%s.%s", c.part, c.example, ext))
 		} else {
 			return filepath.Join(dir, fmt.Sprintf("part%02d.%s", c.part, ext))
 		}
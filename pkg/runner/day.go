@@ -1,207 +1,544 @@
 package runner
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"math"
-	"os"
-	"path/filepath"
+	"io"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
+	"github.com/cockroachdb/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 )
 
+// ErrNotImplemented can be returned by a [Part] which has not been written yet,
+// it is reported in the same way as a nil part.
+var ErrNotImplemented = errors.New("part not implemented")
+
 // Day represents a day of the advent of code
-type Day[Input, Cache any] struct {
+//
+// Part1Answer and Part2Answer are the types of the answers returned by each part.
+type Day[Input, Cache any, Part1Answer, Part2Answer AnswerType] struct {
+	year                int
 	day                 int
 	inputPreprocessor   func([]byte) (Cache, error)
 	cacheToInput        func(Cache) Input
-	part1               Part[Input]
-	part1Answers        answers
-	part2               Part[Input]
-	part2Answers        answers
+	inputStreamer       func(io.Reader, ...stream.ScannerOption) Input // Parses the input as it is read, for streaming days (optional)
+	part1               anyPart[Input]
+	part2               anyPart[Input]
+	part1Reference      anyPart[Input]           // A reference implementation of part 1 to verify against (optional)
+	part2Reference      anyPart[Input]           // A reference implementation of part 2 to verify against (optional)
+	generator           Generator                // Creates random inputs for the day (optional)
+	answers             map[string]*inputAnswers // The known answers for each input, keyed by input name
+	part1Limits         Limits                   // The limits for running part 1, unset limits use the run defaults
+	part2Limits         Limits                   // The limits for running part 2, unset limits use the run defaults
 	expectedPart2Answer *int
 }
 
+// inputAnswers are the known answers for both parts of a single input
+type inputAnswers struct {
+	part1, part2 answers
+}
+
 type answers struct {
-	min, max int
-	answer   *int
+	min, max *Answer  // If set, the answer must be strictly between these bounds (only checked for numeric answers)
+	answer   *Answer  // The expected answer, if known
+	wrong    []Answer // Answers which are known to be wrong
+}
+
+// tooLow returns true if the answer is numeric and at or below the known minimum
+func (a answers) tooLow(answer Answer) bool {
+	if a.min == nil {
+		return false
+	}
+
+	cmp, ok := answer.Cmp(*a.min)
+	return ok && cmp <= 0
+}
+
+// tooHigh returns true if the answer is numeric and at or above the known maximum
+func (a answers) tooHigh(answer Answer) bool {
+	if a.max == nil {
+		return false
+	}
+
+	cmp, ok := answer.Cmp(*a.max)
+	return ok && cmp >= 0
+}
+
+// newInputAnswers returns answers with no known bounds
+func newInputAnswers() *inputAnswers {
+	return &inputAnswers{}
 }
 
 // Part represents a function which given the input will return the answer for that part of the day
-type Part[Input any] func(ctx *Context, log zerolog.Logger, input Input) (answer int, err error)
+type Part[Input any, A AnswerType] func(ctx *Context, log zerolog.Logger, input Input) (answer A, err error)
+
+// anyPart is a [Part] which returns its answer as an [Answer]
+type anyPart[Input any] func(ctx *Context, log zerolog.Logger, input Input) (answer Answer, err error)
+
+// eraseAnswerType converts the part into an [anyPart], returning nil if the part is nil
+func eraseAnswerType[Input any, A AnswerType](part Part[Input, A]) anyPart[Input] {
+	if part == nil {
+		return nil
+	}
+
+	return func(ctx *Context, log zerolog.Logger, input Input) (Answer, error) {
+		answer, err := part(ctx, log, input)
+		if err != nil {
+			return Answer{}, err
+		}
+
+		return AnswerOf(answer), nil
+	}
+}
 
-// NewStreamingDay creates a day which takes a parser to convert the input bytes into a stream of inputs,
+// NewStreamingDay creates a day which takes a parser to convert the input into a stream of inputs,
 // which the part 1 and part 2 functions can then use.
-func NewStreamingDay[Input any](day int, parser func([]byte) stream.Stream[Input], part1, part2 Part[stream.Stream[Input]]) *Day[stream.Stream[Input], []Input] {
-	d := &Day[stream.Stream[Input], []Input]{
-		inputPreprocessor: func(bytes []byte) ([]Input, error) {
-			return stream.Collect(parser(bytes))
+//
+// By default the input is parsed once and each part is given a stream of the parsed inputs, however
+// when run with [RunOptions.StreamInputs] each part is given a stream parsing the input file as it is read.
+//
+// The parser is given the scanner options to read the input with, which when parsing the whole input
+// up front allow tokens as long as the input, and when streaming limit tokens to [RunOptions.MaxTokenSize].
+//
+// The answer types of the parts are inferred from the functions given, so a part which is not
+// implemented yet needs to be given as a typed nil.
+func NewStreamingDay[Input any, Part1Answer, Part2Answer AnswerType](day int, parser func(io.Reader, ...stream.ScannerOption) stream.Stream[Input], part1 Part[stream.Stream[Input], Part1Answer], part2 Part[stream.Stream[Input], Part2Answer]) *Day[stream.Stream[Input], []Input, Part1Answer, Part2Answer] {
+	d := newStreamingDay(day, parser, part1, part2)
+	register(d)
+
+	return d
+}
+
+// newStreamingDay creates a streaming day without registering it
+func newStreamingDay[Input any, Part1Answer, Part2Answer AnswerType](day int, parser func(io.Reader, ...stream.ScannerOption) stream.Stream[Input], part1 Part[stream.Stream[Input], Part1Answer], part2 Part[stream.Stream[Input], Part2Answer]) *Day[stream.Stream[Input], []Input, Part1Answer, Part2Answer] {
+	return &Day[stream.Stream[Input], []Input, Part1Answer, Part2Answer]{
+		inputPreprocessor: func(data []byte) ([]Input, error) {
+			return stream.Collect(parser(bytes.NewReader(data), stream.WithWholeInputBuffer(data)))
 		},
 		cacheToInput: func(cache []Input) stream.Stream[Input] {
 			return stream.From(cache)
 		},
-		day:          day,
-		part1:        part1,
-		part1Answers: answers{min: math.MinInt, max: math.MaxInt},
-		part2:        part2,
-		part2Answers: answers{min: math.MinInt, max: math.MaxInt},
+		inputStreamer: parser,
+		year:          DefaultYear,
+		day:           day,
+		part1:         eraseAnswerType(part1),
+		part2:         eraseAnswerType(part2),
+		answers:       make(map[string]*inputAnswers),
 	}
-
-	days[day] = d
-
-	return d
 }
 
 // NewDay returns a day which is parsed as a whole initially, and then the
 // parsed data is given to each part.
 //
 // See [NewStreamingDay] for a stream processing version
-func NewDay[Input any](day int, parser func([]byte) (Input, error), part1, part2 Part[Input]) *Day[Input, Input] {
-	d := &Day[Input, Input]{
+func NewDay[Input any, Part1Answer, Part2Answer AnswerType](day int, parser func([]byte) (Input, error), part1 Part[Input, Part1Answer], part2 Part[Input, Part2Answer]) *Day[Input, Input, Part1Answer, Part2Answer] {
+	d := &Day[Input, Input, Part1Answer, Part2Answer]{
 		inputPreprocessor: parser,
 		cacheToInput: func(cache Input) Input {
 			return cache
 		},
-		day:          day,
-		part1:        part1,
-		part1Answers: answers{min: math.MinInt, max: math.MaxInt},
-		part2:        part2,
-		part2Answers: answers{min: math.MinInt, max: math.MaxInt},
+		year:    DefaultYear,
+		day:     day,
+		part1:   eraseAnswerType(part1),
+		part2:   eraseAnswerType(part2),
+		answers: make(map[string]*inputAnswers),
 	}
-	days[day] = d
+	register(d)
 
 	return d
 }
 
-func (d *Day[Input, Cache]) Day() int {
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Year() int {
+	return d.year
+}
+
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Day() int {
 	return d.day
 }
 
-func (d *Day[Input, Cache]) WithExpectedAnswers(part1, part2 int) *Day[Input, Cache] {
-	d.part1Answers.answer = &part1
-	d.part2Answers.answer = &part2
+// id returns the ID of the day
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) id() DayID {
+	return DayID{Year: d.year, Day: d.day}
+}
+
+// WithYear sets the year of the event the day is from, which defaults to [DefaultYear]
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithYear(year int) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.year = year
 	return d
 }
 
-func (d *Day[Input, Cache]) WithPart1KnownMax(max int) *Day[Input, Cache] {
-	d.part1Answers.max = max
+// WithExpectedAnswers sets the expected answers for the [DefaultInput]
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithExpectedAnswers(part1 Part1Answer, part2 Part2Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	return d.WithExpectedAnswersFor(DefaultInput, part1, part2)
+}
+
+// WithExpectedAnswersFor sets the expected answers for the named input
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithExpectedAnswersFor(input string, part1 Part1Answer, part2 Part2Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	answers := d.answersFor(input)
+	answers.part1.answer = ptr(AnswerOf(part1))
+	answers.part2.answer = ptr(AnswerOf(part2))
 	return d
 }
 
-func (d *Day[Input, Cache]) WithPart2KnownMax(max int) *Day[Input, Cache] {
-	d.part2Answers.max = max
+// WithPart1KnownMin sets a value which the part 1 answer for the [DefaultInput] must be above
+//
+// This is only checked if the answer is numeric.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart1KnownMin(min Part1Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.answersFor(DefaultInput).part1.min = ptr(AnswerOf(min))
 	return d
 }
 
-// Run executes the given parts with the given input
+// WithPart2KnownMin sets a value which the part 2 answer for the [DefaultInput] must be above
 //
-// If an error is encountered, the program will exit with a non-zero exit code
-func (d *Day[Input, Cache]) Run(ctx context.Context, saveOutput bool) {
-	logger := log.With().Int("_day", d.day).Logger()
-
-	// Read the input
-	readStart := time.Now()
-	inputFile := filepath.Join(repoDir, "inputs", fmt.Sprintf("day%02d.txt", d.day))
-	input, err := os.ReadFile(inputFile)
-	if err != nil {
-		logger.Err(err).Str("file", inputFile).Msg("failed to read input file")
-		os.Exit(1)
-		return
+// This is only checked if the answer is numeric.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart2KnownMin(min Part2Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.answersFor(DefaultInput).part2.min = ptr(AnswerOf(min))
+	return d
+}
+
+// WithPart1KnownMax sets a value which the part 1 answer for the [DefaultInput] must be below
+//
+// This is only checked if the answer is numeric.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart1KnownMax(max Part1Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.answersFor(DefaultInput).part1.max = ptr(AnswerOf(max))
+	return d
+}
+
+// WithPart2KnownMax sets a value which the part 2 answer for the [DefaultInput] must be below
+//
+// This is only checked if the answer is numeric.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart2KnownMax(max Part2Answer) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.answersFor(DefaultInput).part2.max = ptr(AnswerOf(max))
+	return d
+}
+
+// WithTimeout sets how long each part of the day can run for
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithTimeout(timeout time.Duration) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part1Limits.Timeout = timeout
+	d.part2Limits.Timeout = timeout
+	return d
+}
+
+// WithPart1Timeout sets how long part 1 can run for
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart1Timeout(timeout time.Duration) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part1Limits.Timeout = timeout
+	return d
+}
+
+// WithPart2Timeout sets how long part 2 can run for
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithPart2Timeout(timeout time.Duration) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part2Limits.Timeout = timeout
+	return d
+}
+
+// WithHeapBudget sets how many bytes the live heap can grow by while each part of the day runs
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithHeapBudget(bytes uint64) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part1Limits.HeapBudget = bytes
+	d.part2Limits.HeapBudget = bytes
+	return d
+}
+
+// limitsFor returns the limits for the given part, using the defaults for any the day hasn't set
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) limitsFor(partNum int, defaults Limits) Limits {
+	if partNum == 1 {
+		return d.part1Limits.orDefault(defaults)
+	}
+
+	return d.part2Limits.orDefault(defaults)
+}
+
+// answersFor returns the known answers for the named input, creating them if needed
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) answersFor(input string) *inputAnswers {
+	answers, found := d.answers[input]
+	if !found {
+		answers = newInputAnswers()
+		d.answers[input] = answers
+	}
+
+	return answers
+}
+
+// RunOptions controls how a day is run
+type RunOptions struct {
+	InputOptions              // Where to read the inputs from
+	SaveOutput   bool         // Record output to file
+	Answers      *AnswersFile // Expected answers to merge with those registered in code (optional)
+	Guesses      *GuessLog    // Previous guesses used to tighten the known answers (optional)
+	Limits       Limits       // The limits for any part which doesn't set its own
+	LiveProgress io.Writer    // Where to draw live progress lines for interactive runs, if not set progress is logged
+	Profiles     Profiles     // The profiles to record around each part
+	StreamInputs bool         // Give the parts of streaming days a stream reading the input file, rather than reading it into memory first
+	MaxTokenSize int          // The longest token, such as a line, a streamed input can contain (defaults to bufio.MaxScanTokenSize)
+}
+
+// knownAnswers returns the known answers for the named input, merging those registered
+// in code with any in the answers file and the verdicts of previous guesses. Answers
+// registered in code take priority.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) knownAnswers(input string, file *AnswersFile, guesses *GuessLog) inputAnswers {
+	known := *newInputAnswers()
+	if registered, found := d.answers[input]; found {
+		known = *registered
+		known.part1.wrong = slices.Clone(known.part1.wrong)
+		known.part2.wrong = slices.Clone(known.part2.wrong)
+	}
+
+	part1, part2 := file.lookup(d.id(), input)
+	if known.part1.answer == nil {
+		known.part1.answer = part1
+	}
+	if known.part2.answer == nil {
+		known.part2.answer = part2
+	}
+
+	guesses.applyTo(d.id(), input, &known)
+
+	return known
+}
+
+// logger returns a logger with fields identifying the day
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) logger(base *zerolog.Logger) zerolog.Logger {
+	logCtx := base.With()
+	if d.year != DefaultYear {
+		logCtx = logCtx.Int("_year", d.year)
+	}
+
+	return logCtx.Int("_day", d.day).Logger()
+}
+
+// Run executes the given parts against each of the days inputs
+//
+// Any errors encountered are logged and recorded in the returned results,
+// of which there is one per input.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Run(ctx context.Context, opts RunOptions) []DayResult {
+	logger := d.logger(log.Ctx(ctx))
+
+	readInputs := opts.readInputs
+	if d.streamsInputs(opts) {
+		readInputs = opts.findInputs
 	}
 
-	// Preprocess the input
-	cacheData, err := d.inputPreprocessor(input)
+	inputs, err := readInputs(d.id())
 	if err != nil {
-		logger.Err(err).Msg("failed to preprocess input")
-		os.Exit(1)
-		return
+		logger.Err(err).Msg("failed to read inputs")
+		return []DayResult{{Year: d.year, Day: d.day, Err: err}}
 	}
-	logger.Info().Str("duration", time.Since(readStart).String()).Msg("days input parsed")
 
-	runPart := func(partNum int, fn Part[Input], answers answers) {
-		partCtx := &Context{
-			Context:    ctx,
-			day:        d.day,
-			part:       partNum,
-			saveOutput: saveOutput,
+	results := make([]DayResult, 0, len(inputs))
+	for _, input := range inputs {
+		results = append(results, d.runInput(ctx, logger, opts, input))
+	}
+
+	return results
+}
+
+// runInput runs both parts against a single input
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) runInput(ctx context.Context, logger zerolog.Logger, opts RunOptions, input namedInput) (result DayResult) {
+	result.Year = d.year
+	result.Day = d.day
+	result.Input = input.name
+	if input.name != DefaultInput {
+		logger = logger.With().Str("_input", input.name).Logger()
+	}
+
+	// Preprocess the input, unless each part is parsing it as it reads it
+	var partInput func() (Input, func(), error)
+	if d.streamsInputs(opts) {
+		partInput = d.streamInput(input, opts.MaxTokenSize)
+		logger.Debug().Str("file", input.path).Msg("streaming input to each part")
+	} else {
+		parseStart := time.Now()
+		cacheData, err := d.inputPreprocessor(input.data)
+		result.ParseDuration = time.Since(parseStart)
+		if err != nil {
+			logger.Err(err).Str("file", input.path).Msg("failed to preprocess input")
+			result.Err = errors.Wrap(err, "failed to preprocess input")
+			return result
+		}
+		logger.Info().Str("duration", result.ParseDuration.String()).Msg("days input parsed")
+
+		partInput = func() (Input, func(), error) {
+			return d.cacheToInput(cacheData), func() {}, nil
+		}
+	}
+
+	known := d.knownAnswers(input.name, opts.Answers, opts.Guesses)
+
+	runPart := func(partNum int, fn anyPart[Input], answers answers) (result PartResult) {
+		result.Part = partNum
+		result.Expected = answers.answer
+
+		if fn == nil {
+			logger.Warn().Caller(1).Int("part", partNum).Msg("part not implemented")
+			result.Status = StatusNotImplemented
+			return result
 		}
 
-		if fn != nil {
-			logger := logger.With().Int("_part", partNum).Logger()
+		logger := logger.With().Int("_part", partNum).Logger()
+
+		progress := newProgressReporter(logger, opts.LiveProgress)
+		newPartCtx := func(ctx context.Context) *Context {
+			return &Context{
+				Context:    ctx,
+				log:        logger,
+				year:       d.year,
+				day:        d.day,
+				part:       partNum,
+				saveOutput: opts.SaveOutput,
+				progress:   progress,
+			}
+		}
+
+		var stopProfiles func() error
+		if opts.Profiles.Any() {
+			var err error
+			if stopProfiles, err = opts.Profiles.start(newPartCtx(ctx), input.name); err != nil {
+				logger.Err(err).Msg("failed to start profiling part")
+			}
+		}
 
-			start := time.Now()
-			answer, err := fn(partCtx, logger, d.cacheToInput(cacheData))
-			dur := time.Since(start)
+		start := time.Now()
+		var abandoned bool
+		result.Answer, abandoned, result.Err = runLimited(ctx, d.limitsFor(partNum, opts.Limits), func(ctx context.Context) (Answer, error) {
+			input, done, err := partInput()
 			if err != nil {
-				logger.Err(err).Str("duration", dur.String()).Msg("failed to run part")
-				os.Exit(1)
-				return
-			} else {
-				if answers.answer != nil && answer != *answers.answer {
-					logger.Error().Caller(1).Str("duration", dur.String()).Int("got", answer).Int("expected", *answers.answer).Msg("part returned wrong answer")
-					os.Exit(1)
-					return
-				}
-
-				if answers.min >= answer {
-					logger.Error().Caller(1).Str("duration", dur.String()).Int("got", answer).Int("min", answers.min).Msg("part returned answer below hinted minimum")
-					os.Exit(1)
-					return
-				}
-
-				if answers.max <= answer {
-					logger.Error().Caller(1).Str("duration", dur.String()).Int("got", answer).Int("max", answers.max).Msg("part returned answer above hinted maximum")
-					os.Exit(1)
-					return
-				}
-
-				logger.Info().Caller(1).Str("duration", dur.String()).Int("answer", answer).Msg("part complete")
+				return Answer{}, err
 			}
-		} else {
-			logger.Warn().Caller(1).Int("part", 1).Msg("part not implemented")
+			defer done()
+
+			return fn(newPartCtx(ctx), logger, input)
+		})
+		result.Duration = time.Since(start)
+		progress.finish()
+
+		if stopProfiles != nil {
+			if err := stopProfiles(); err != nil {
+				logger.Err(err).Msg("failed to write part profiles")
+			}
+		}
+		answer := result.Answer
+		dur := result.Duration
+
+		if abandoned {
+			logger.Warn().Caller(1).Msg("part ignored its context being cancelled and is still running in the background")
+		}
+
+		switch {
+		case errors.Is(result.Err, ErrNotImplemented):
+			logger.Warn().Caller(1).Msg("part not implemented")
+			result.Status = StatusNotImplemented
+			result.Err = nil
+
+		case errors.Is(result.Err, ErrTimedOut):
+			logger.Error().Caller(1).Err(result.Err).Str("duration", dur.String()).Msg("part timed out")
+			result.Status = StatusTimedOut
+
+		case errors.Is(result.Err, ErrHeapBudgetExceeded):
+			logger.Error().Caller(1).Err(result.Err).Str("duration", dur.String()).Msg("part exceeded its heap budget")
+			result.Status = StatusHeapBudgetExceeded
+
+		case result.Err != nil:
+			logger.Err(result.Err).Str("duration", dur.String()).Msg("failed to run part")
+			result.Status = StatusError
+
+		case slices.ContainsFunc(answers.wrong, answer.Equal):
+			logger.Error().Caller(1).Str("duration", dur.String()).Stringer("got", answer).Msg("part returned an answer already known to be wrong")
+			result.Status = StatusWrong
+
+		case answers.answer != nil && !answer.Equal(*answers.answer):
+			logger.Error().Caller(1).Str("duration", dur.String()).Stringer("got", answer).Stringer("expected", answers.answer).Msg("part returned wrong answer")
+			result.Status = StatusWrong
+
+		case answers.tooLow(answer):
+			logger.Error().Caller(1).Str("duration", dur.String()).Stringer("got", answer).Stringer("min", answers.min).Msg("part returned answer below hinted minimum")
+			result.Status = StatusTooLow
+
+		case answers.tooHigh(answer):
+			logger.Error().Caller(1).Str("duration", dur.String()).Stringer("got", answer).Stringer("max", answers.max).Msg("part returned answer above hinted maximum")
+			result.Status = StatusTooHigh
+
+		default:
+			logger.Info().Caller(1).Str("duration", dur.String()).Stringer("answer", answer).Msg("part complete")
+			result.Status = StatusOK
 		}
+
+		return result
+	}
+
+	// Run both parts, even if part 1 fails
+	result.Parts = []PartResult{
+		runPart(1, d.part1, known.part1),
+		runPart(2, d.part2, known.part2),
 	}
 
-	// Run part 1 if it exists
-	runPart(1, d.part1, d.part1Answers)
-	runPart(2, d.part2, d.part2Answers)
+	return result
+}
+
+// streamsInputs returns true if the parts should be given a stream reading the input
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) streamsInputs(opts RunOptions) bool {
+	return opts.StreamInputs && d.inputStreamer != nil
+}
+
+// streamInput returns a function which opens the input and returns a stream parsing it,
+// along with a function to close the input once the part has finished with it.
+//
+// If maxTokenSize is set, the stream allows tokens up to that size rather than the scanner default.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) streamInput(input namedInput, maxTokenSize int) func() (Input, func(), error) {
+	var options []stream.ScannerOption
+	if maxTokenSize > 0 {
+		options = append(options, stream.WithBuffer(0, maxTokenSize))
+	}
+
+	return func() (Input, func(), error) {
+		f, err := input.open()
+		if err != nil {
+			var zero Input
+			return zero, nil, err
+		}
+
+		return d.inputStreamer(f, options...), func() { _ = f.Close() }, nil
+	}
 }
 
 // Test runs the given parts with the given input and asserts the answers
-func (d *Day[Input, Cache]) Test(t *testing.T, part1TestInput string, part1ExpectedAnswer int, part2estInput string, part2ExpectedAnswer int) {
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Test(t *testing.T, part1TestInput string, part1ExpectedAnswer Part1Answer, part2estInput string, part2ExpectedAnswer Part2Answer) {
 	t.Helper()
 	t.Parallel()
 
-	d.testPart(t, "part1", 1, d.part1, part1TestInput, part1ExpectedAnswer)
-	d.testPart(t, "part2", 2, d.part2, part2estInput, part2ExpectedAnswer)
+	d.testPart(t, "part1", 1, d.part1, part1TestInput, "", AnswerOf(part1ExpectedAnswer))
+	d.testPart(t, "part2", 2, d.part2, part2estInput, "", AnswerOf(part2ExpectedAnswer))
 }
 
 // TestPart1 runs the given part 1 with the given input and asserts the answer
-func (d *Day[Input, Cache]) TestPart1(t *testing.T, input string, expectedAnswer int) {
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) TestPart1(t *testing.T, input string, expectedAnswer Part1Answer) {
 	t.Helper()
 
-	d.testPart(t, fmt.Sprintf("part1_%s", input), 1, d.part1, input, expectedAnswer)
+	d.testPart(t, fmt.Sprintf("part1_%s", testNameFor(input)), 1, d.part1, input, "", AnswerOf(expectedAnswer))
 }
 
 // TestPart2 runs the given part 2 with the given input and asserts the answer
-func (d *Day[Input, Cache]) TestPart2(t *testing.T, input string, expectedAnswer int) {
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) TestPart2(t *testing.T, input string, expectedAnswer Part2Answer) {
 	t.Helper()
-	d.testPart(t, fmt.Sprintf("part2_%s", input), 2, d.part2, input, expectedAnswer)
+	d.testPart(t, fmt.Sprintf("part2_%s", testNameFor(input)), 2, d.part2, input, "", AnswerOf(expectedAnswer))
 }
 
 // TestContext creates a context for testing with
-func (d *Day[Input, Cache]) TestContext(t *testing.T, partNum int) *Context {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//
+// The context is cancelled once the parts timeout has passed, or [DefaultTestTimeout] if the day doesn't set one.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) TestContext(t *testing.T, partNum int) *Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d.limitsFor(partNum, Limits{Timeout: DefaultTestTimeout}).Timeout)
 	t.Cleanup(cancel)
 
 	return &Context{
 		Context:    ctx,
+		year:       d.year,
 		day:        d.day,
 		part:       partNum,
 		test:       t,
@@ -211,37 +548,59 @@ func (d *Day[Input, Cache]) TestContext(t *testing.T, partNum int) *Context {
 }
 
 // testPart runs the given part with the given input and asserts the answer
-func (d *Day[Input, Cache]) testPart(t *testing.T, testName string, partNum int, fn Part[Input], input string, expectedAnswer int) {
+//
+// If the input is an example from testdata, example is its name, otherwise it is empty.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) testPart(t *testing.T, testName string, partNum int, fn anyPart[Input], input string, example string, expectedAnswer Answer) {
 	t.Helper()
 	t.Run(testName, func(t *testing.T) {
 		t.Parallel()
 
-		baseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		ctx := &Context{
-			Context:    baseCtx,
-			day:        d.day,
-			part:       partNum,
-			isTest:     true,
-			saveOutput: true,
-		}
-
 		if fn == nil {
 			t.Skip("Part not implemented")
 		}
-		if fn == nil && expectedAnswer != 0 {
-			assert.FailNow(t, "answer provided, but part not implemented yet")
+
+		answer, err := d.runTestPart(t, partNum, fn, input, example)
+		if errors.Is(err, ErrNotImplemented) {
+			t.Skip("Part not implemented")
+		} else if errors.Is(err, ErrGoldenMismatch) {
+			// Report the error without its stack trace, so the diff is readable
+			t.Error(err.Error())
+		} else {
+			assert.NoError(t, err)
 		}
+		assert.Equal(t, expectedAnswer.String(), answer.String(), "Part answer incorrect")
+	})
+}
+
+// runTestPart parses the input and runs the given part against it, returning the answer
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) runTestPart(t *testing.T, partNum int, fn anyPart[Input], input string, example string) (Answer, error) {
+	t.Helper()
 
-		// drop to trace level for tests
-		testLogger := log.Level(zerolog.TraceLevel).With().Int("_part", partNum).Logger()
+	// drop to trace level for tests
+	testLogger := log.Level(zerolog.TraceLevel).With().Int("_part", partNum).Logger()
+
+	preppedData, err := d.inputPreprocessor([]byte(strings.TrimSpace(input)))
+	if err != nil {
+		return Answer{}, errors.Wrap(err, "failed to preprocess input")
+	}
 
-		preppedData, err := d.inputPreprocessor([]byte(strings.TrimSpace(input)))
-		assert.NoError(t, err, "Failed to preprocess input")
+	limits := d.limitsFor(partNum, Limits{Timeout: DefaultTestTimeout})
+	answer, abandoned, err := runLimited(context.Background(), limits, func(ctx context.Context) (Answer, error) {
+		partCtx := &Context{
+			Context:    ctx,
+			year:       d.year,
+			day:        d.day,
+			part:       partNum,
+			isTest:     true,
+			example:    example,
+			saveOutput: true,
+		}
 
-		answer, err := fn(ctx, testLogger, d.cacheToInput(preppedData))
-		assert.NoError(t, err)
-		assert.Equal(t, expectedAnswer, answer, "Part answer incorrect")
+		return fn(partCtx, testLogger, d.cacheToInput(preppedData))
 	})
+	if abandoned {
+		t.Log("Part ignored its context being cancelled and is still running")
+	}
+
+	return answer, err
 }
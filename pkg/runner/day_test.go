@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DomBlack/advent-of-code-2023/pkg/stream"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDay_Run_StreamInputs(t *testing.T) {
+
+	d := newStreamingDay(1, stream.LinesFromReader, countLines, countLines)
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0644))
+
+	for _, streaming := range []bool{false, true} {
+		results := d.Run(context.Background(), RunOptions{InputOptions: InputOptions{File: path}, StreamInputs: streaming})
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Err)
+
+		for _, part := range results[0].Parts {
+			assert.Equal(t, StatusOK, part.Status, "streaming=%v part %d", streaming, part.Part)
+			assert.Equal(t, AnswerOf(3), part.Answer, "each part should read the whole input, streaming=%v part %d", streaming, part.Part)
+		}
+
+		if streaming {
+			assert.Zero(t, results[0].ParseDuration, "the input should not be parsed up front when streaming")
+		}
+	}
+}
+
+func TestDay_Run_LongLines(t *testing.T) {
+	d := newStreamingDay(1, stream.LinesFromReader, countLines, countLines)
+
+	long := strings.Repeat("x", 2*bufio.MaxScanTokenSize)
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte("one\n"+long+"\nthree\n"), 0644))
+
+	tests := []struct {
+		name         string
+		streaming    bool
+		maxTokenSize int
+		status       Status
+	}{
+		{name: "in memory", status: StatusOK},
+		{name: "in memory ignores the max token size", maxTokenSize: 16, status: StatusOK},
+		{name: "streaming", streaming: true, status: StatusError},
+		{name: "streaming with max token size", streaming: true, maxTokenSize: len(long) + 1, status: StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := d.Run(context.Background(), RunOptions{
+				InputOptions: InputOptions{File: path},
+				StreamInputs: test.streaming,
+				MaxTokenSize: test.maxTokenSize,
+			})
+			require.Len(t, results, 1)
+			require.NoError(t, results[0].Err)
+
+			for _, part := range results[0].Parts {
+				assert.Equal(t, test.status, part.Status, "part %d", part.Part)
+				if test.status == StatusOK {
+					assert.Equal(t, AnswerOf(3), part.Answer, "part %d", part.Part)
+				} else {
+					assert.ErrorIs(t, part.Err, bufio.ErrTooLong, "part %d", part.Part)
+				}
+			}
+		})
+	}
+}
+
+// countLines is a part which counts the lines in the input
+var countLines Part[stream.Stream[string], int] = func(_ *Context, _ zerolog.Logger, input stream.Stream[string]) (int, error) {
+	lines, err := stream.Collect(input)
+	return len(lines), err
+}
+
+// newTestDay returns an unregistered day whose input is a single integer
+func newTestDay(part1, part2 Part[int, int]) *Day[int, int, int, int] {
+	return &Day[int, int, int, int]{
+		year: 1999,
+		day:  1,
+		inputPreprocessor: func(data []byte) (int, error) {
+			return strconv.Atoi(strings.TrimSpace(string(data)))
+		},
+		cacheToInput: func(n int) int { return n },
+		part1:        eraseAnswerType(part1),
+		part2:        eraseAnswerType(part2),
+	}
+}
@@ -0,0 +1,216 @@
+package runner
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// update is set by running the tests with -update, which rewrites the expected
+// answers and golden files in testdata with what the parts actually return
+//
+// The flag is only registered in test binaries, so it doesn't appear in the flags of commands using the runner.
+var update = new(bool)
+
+func init() {
+	if testing.Testing() {
+		flag.BoolVar(update, "update", false, "update the expected answers and golden files in testdata")
+	}
+}
+
+// exampleSeparator separates the header of an example file from the input
+const exampleSeparator = "---"
+
+// maxTestNameLength is the longest part of an input used to name a test
+const maxTestNameLength = 20
+
+// example is a puzzle example read from testdata/examples
+//
+// The file starts with a header giving the expected answer of each part,
+// followed by a separator line and then the input:
+//
+//	part1: 136
+//	part2: 64
+//	---
+//	O....#....
+//	O.OO#....#
+//
+// Either answer can be left out, in which case that part is not tested.
+type example struct {
+	name  string  // The name of the example, taken from the file name
+	path  string  // Where the example was read from
+	part1 *Answer // The expected answer to part 1, if known
+	part2 *Answer // The expected answer to part 2, if known
+	input string  // The puzzle input
+}
+
+// examplesDir returns the directory holding the examples for the day
+func examplesDir(id DayID) string {
+	return filepath.Join(id.PackageDir(), "testdata", "examples")
+}
+
+// readExamples reads every example for the day, in file name order
+func readExamples(id DayID) ([]*example, error) {
+	paths, err := filepath.Glob(filepath.Join(examplesDir(id), "*.txt"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find examples")
+	}
+
+	examples := make([]*example, 0, len(paths))
+	for _, path := range paths {
+		ex, err := readExample(path)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, ex)
+	}
+
+	return examples, nil
+}
+
+// readExample reads and parses the example at the given path
+func readExample(path string) (*example, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read example")
+	}
+
+	// The leading newline allows for examples with an empty header
+	header, input, found := strings.Cut("\n"+string(data), "\n"+exampleSeparator+"\n")
+	if !found {
+		return nil, errors.Newf("example %s has no %q line separating the header from the input", path, exampleSeparator)
+	}
+
+	ex := &example{
+		name:  strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		path:  path,
+		input: input,
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(header))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, errors.Newf("invalid header line %q in example %s, expected part1: or part2:", line, path)
+		}
+
+		answer := ptr(ParseAnswer(strings.TrimSpace(value)))
+		switch strings.TrimSpace(key) {
+		case "part1":
+			ex.part1 = answer
+		case "part2":
+			ex.part2 = answer
+		default:
+			return nil, errors.Newf("unknown header %q in example %s, expected part1 or part2", key, path)
+		}
+	}
+
+	return ex, nil
+}
+
+// write saves the example back to the file it was read from
+func (e *example) write() error {
+	var sb strings.Builder
+	if e.part1 != nil {
+		_, _ = fmt.Fprintf(&sb, "part1: %s\n", e.part1)
+	}
+	if e.part2 != nil {
+		_, _ = fmt.Fprintf(&sb, "part2: %s\n", e.part2)
+	}
+	sb.WriteString(exampleSeparator + "\n")
+	sb.WriteString(e.input)
+
+	if err := os.WriteFile(e.path, []byte(sb.String()), 0644); err != nil {
+		return errors.Wrap(err, "failed to write example")
+	}
+
+	return nil
+}
+
+// TestExamples runs every example in the days testdata/examples directory, with each
+// example being a subtest named after its file.
+//
+// If the tests are run with -update, rather than checking the answers, the header of
+// each example is rewritten with the answers the parts returned.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) TestExamples(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	examples, err := readExamples(d.id())
+	require.NoError(t, err)
+	require.NotEmpty(t, examples, "No examples found in %s", examplesDir(d.id()))
+
+	for _, ex := range examples {
+		t.Run(ex.name, func(t *testing.T) {
+			if *update {
+				d.updateExample(t, ex)
+				return
+			}
+
+			if ex.part1 != nil {
+				d.testPart(t, "part1", 1, d.part1, ex.input, ex.name, *ex.part1)
+			}
+			if ex.part2 != nil {
+				d.testPart(t, "part2", 2, d.part2, ex.input, ex.name, *ex.part2)
+			}
+		})
+	}
+}
+
+// updateExample runs the parts the example has an answer for and rewrites their expected answers
+//
+// Parts without an answer in the header are not run, as the example may not be valid input
+// for them. Any part which is not implemented keeps its existing answer, and if a part
+// returns an error the example is left unchanged.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) updateExample(t *testing.T, ex *example) {
+	t.Helper()
+
+	parts := []struct {
+		fn       anyPart[Input]
+		expected **Answer
+	}{
+		{d.part1, &ex.part1},
+		{d.part2, &ex.part2},
+	}
+
+	for i, part := range parts {
+		if part.fn == nil || *part.expected == nil {
+			continue
+		}
+
+		answer, err := d.runTestPart(t, i+1, part.fn, ex.input, ex.name)
+		if errors.Is(err, ErrNotImplemented) {
+			continue
+		} else if err != nil {
+			t.Errorf("Part %d failed, leaving the example unchanged: %v", i+1, err)
+			return
+		}
+
+		*part.expected = &answer
+	}
+
+	require.NoError(t, ex.write())
+}
+
+// testNameFor returns a short name for a test using the given input,
+// taken from its first line
+func testNameFor(input string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(input), "\n")
+	if len(name) > maxTestNameLength {
+		name = name[:maxTestNameLength]
+	}
+
+	return name
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExample_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "small.txt")
+	require.NoError(t, os.WriteFile(path, []byte("part2: ABC\n---\n1 2 3\n4 5 6\n"), 0644))
+
+	ex, err := readExample(path)
+	require.NoError(t, err)
+	assert.Equal(t, "small", ex.name)
+	assert.Nil(t, ex.part1)
+	require.NotNil(t, ex.part2)
+	assert.Equal(t, AnswerOf("ABC"), *ex.part2)
+	assert.Equal(t, "1 2 3\n4 5 6\n", ex.input)
+
+	ex.part1 = ptr(AnswerOf(42))
+	require.NoError(t, ex.write())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "part1: 42\npart2: ABC\n---\n1 2 3\n4 5 6\n", string(data))
+}
+
+func TestExample_Invalid(t *testing.T) {
+	for name, contents := range map[string]string{
+		"no separator":   "part1: 1\n1 2 3\n",
+		"unknown header": "part3: 1\n---\n1 2 3\n",
+		"no colon":       "part1 1\n---\n1 2 3\n",
+	} {
+		path := filepath.Join(t.TempDir(), "example.txt")
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+
+		_, err := readExample(path)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTestNameFor(t *testing.T) {
+	assert.Equal(t, "0 3 6 9 12 15", testNameFor("\n0 3 6 9 12 15\n1 3 6 10 15 21\n"))
+	assert.Equal(t, "abcdefghijklmnopqrst", testNameFor("abcdefghijklmnopqrstuvwxyz"))
+}
+
+func TestDay_UpdateExample_PartOnly(t *testing.T) {
+	d := newTestDay(
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			panic("part 1 should not run on a part 2 only example")
+		},
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n * 2, nil
+		},
+	)
+
+	path := filepath.Join(t.TempDir(), "example.txt")
+	require.NoError(t, os.WriteFile(path, []byte("part2: 1\n---\n21\n"), 0644))
+
+	ex, err := readExample(path)
+	require.NoError(t, err)
+	d.updateExample(t, ex)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "part2: 42\n---\n21\n", string(data), "only the part in the header should be updated")
+}
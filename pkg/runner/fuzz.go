@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzParser turns the days parser into a fuzz target, with the corpus seeded from
+// the days examples in testdata/examples along with any extra seeds given.
+//
+// The target checks the parser never panics. If printer is not nil, it must convert the
+// parsed input back into the puzzle format, and every input which parses successfully is
+// checked to round-trip; that is printing it, parsing the printed input and printing that
+// again gives the same output.
+//
+// When run by go test the seed corpus is checked, to fuzz the parser run:
+//
+//	go test ./internal/day02 -run '^$' -fuzz FuzzParser
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) FuzzParser(f *testing.F, printer func(Cache) string, seeds ...string) {
+	f.Helper()
+
+	examples, err := readExamples(d.id())
+	require.NoError(f, err)
+	for _, ex := range examples {
+		f.Add([]byte(ex.input))
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parsed, err := d.parseForFuzzing(t, input)
+		if err != nil || printer == nil {
+			return
+		}
+
+		printed := printer(parsed)
+		reparsed, err := d.parseForFuzzing(t, []byte(printed))
+		require.NoError(t, err, "Printed input failed to parse:\n%s", printed)
+		require.Equal(t, printed, printer(reparsed), "Printed input did not round-trip")
+	})
+}
+
+// parseForFuzzing parses the input, failing the test if the parser panics
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) parseForFuzzing(t *testing.T, input []byte) (parsed Cache, err error) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Parser panicked on input %q: %v", input, r)
+		}
+	}()
+
+	return d.inputPreprocessor(input)
+}
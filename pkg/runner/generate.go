@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ErrNoGenerator is returned when generating an input for a day which has no generator
+var ErrNoGenerator = errors.New("day has no input generator")
+
+// Generator creates a random input for a day in the puzzle format
+//
+// The size controls roughly how large the input is, such as the width of a grid or the
+// number of lines, and an rng seeded the same way must always give the same input.
+type Generator func(rng *rand.Rand, size int) []byte
+
+// WithGenerator registers a generator of random inputs for the day, which are used
+// by [Day.Verify] and can be written out by the gen subcommand
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithGenerator(generator Generator) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.generator = generator
+	return d
+}
+
+// Generate creates a random input of the given size for the day, returning [ErrNoGenerator]
+// if the day doesn't have a generator
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Generate(rng *rand.Rand, size int) ([]byte, error) {
+	if d.generator == nil {
+		return nil, errors.Wrapf(ErrNoGenerator, "%s", d.id())
+	}
+	if size < 1 {
+		return nil, errors.Newf("size must be at least 1, got %d", size)
+	}
+
+	return d.generator(rng, size), nil
+}
+
+// TestGenerator checks inputs created by the days generator, across a range of seeds
+// and sizes, can be parsed and that neither part returns an error for them
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) TestGenerator(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	for _, size := range []int{1, 5, 20} {
+		for seed := int64(1); seed <= 5; seed++ {
+			input, err := d.Generate(rand.New(rand.NewSource(seed)), size)
+			require.NoError(t, err)
+
+			t.Run(fmt.Sprintf("size=%d/seed=%d", size, seed), func(t *testing.T) {
+				t.Parallel()
+
+				for partNum, fn := range []anyPart[Input]{d.part1, d.part2} {
+					if fn == nil {
+						continue
+					}
+
+					// Run as when verifying, so no output is saved and any panic is reported as an error
+					_, err := d.runForVerify(context.Background(), partNum+1, fn, input)
+					if !errors.Is(err, ErrNotImplemented) {
+						assert.NoError(t, err, "Part %d failed on generated input:\n%s", partNum+1, input)
+					}
+				}
+			})
+		}
+	}
+}
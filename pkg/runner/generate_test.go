@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDay_Generate(t *testing.T) {
+	d := &Day[int, int, int, int]{year: 1999, day: 1}
+
+	_, err := d.Generate(rand.New(rand.NewSource(1)), 10)
+	assert.True(t, errors.Is(err, ErrNoGenerator), "expected ErrNoGenerator, got %v", err)
+
+	d.WithGenerator(func(rng *rand.Rand, size int) []byte {
+		return []byte(strconv.Itoa(rng.Intn(size)))
+	})
+
+	_, err = d.Generate(rand.New(rand.NewSource(1)), 0)
+	assert.Error(t, err, "size of zero should be rejected")
+
+	first, err := d.Generate(rand.New(rand.NewSource(42)), 1000)
+	require.NoError(t, err)
+	second, err := d.Generate(rand.New(rand.NewSource(42)), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "same seed should generate the same input")
+}
@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrGoldenMismatch is returned when a part's output does not match its golden file
+var ErrGoldenMismatch = errors.New("output does not match golden file")
+
+// maxPixelDiffs is the most differing pixels listed when an image does not match its golden file
+const maxPixelDiffs = 5
+
+// GoldenText checks a text rendering of the part (such as a maps.Map from its String method) against the golden file
+// for the part, returning an error with a diff of the two if they do not match.
+//
+// The golden file is found at [Context.OutputFile] with the given extension, so when running as a test
+// it is stored in the days testdata directory. Running the tests with -update writes the golden file
+// rather than checking it. When not running as a test, the text is written to the outputs directory
+// if the output is being saved.
+func (c *Context) GoldenText(ext string, text string) error {
+	return c.golden(ext, []byte(text), func(want []byte) string {
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(want)),
+			B:        difflib.SplitLines(text),
+			FromFile: "golden",
+			ToFile:   "output",
+			Context:  2,
+		})
+		return diff
+	})
+}
+
+// GoldenImage checks an image rendered by the part against the golden file for the part,
+// which is stored as a PNG. See [Context.GoldenText] for where the golden file is stored.
+func (c *Context) GoldenImage(ext string, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return errors.Wrap(err, "failed to encode png")
+	}
+
+	return c.golden(ext, buf.Bytes(), func(want []byte) string {
+		wantImg, err := png.Decode(bytes.NewReader(want))
+		if err != nil {
+			return fmt.Sprintf("golden file is not a valid png: %v", err)
+		}
+
+		return describeImageDiff(wantImg, img)
+	})
+}
+
+// GoldenGIF checks an animation rendered by the part against the golden file for the part.
+// See [Context.GoldenText] for where the golden file is stored.
+func (c *Context) GoldenGIF(ext string, g *gif.GIF) error {
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return errors.Wrap(err, "failed to encode gif")
+	}
+
+	return c.golden(ext, buf.Bytes(), func(want []byte) string {
+		wantGIF, err := gif.DecodeAll(bytes.NewReader(want))
+		if err != nil {
+			return fmt.Sprintf("golden file is not a valid gif: %v", err)
+		}
+		gotGIF, err := gif.DecodeAll(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			return fmt.Sprintf("output is not a valid gif: %v", err)
+		}
+
+		if len(wantGIF.Image) != len(gotGIF.Image) {
+			return fmt.Sprintf("golden has %d frames, output has %d frames", len(wantGIF.Image), len(gotGIF.Image))
+		}
+
+		var sb strings.Builder
+		for i := range wantGIF.Image {
+			if wantGIF.Delay[i] != gotGIF.Delay[i] {
+				_, _ = fmt.Fprintf(&sb, "frame %d: golden delay %d, output delay %d\n", i, wantGIF.Delay[i], gotGIF.Delay[i])
+			}
+			if diff := describeImageDiff(wantGIF.Image[i], gotGIF.Image[i]); diff != "" {
+				_, _ = fmt.Fprintf(&sb, "frame %d: %s\n", i, diff)
+			}
+		}
+		return sb.String()
+	})
+}
+
+// golden checks data against the golden file with the given extension, using describe
+// to explain how the golden file differs if they do not match
+func (c *Context) golden(ext string, data []byte, describe func(want []byte) string) error {
+	if !c.SaveOutput() {
+		return nil
+	}
+
+	path := c.OutputFile(ext)
+	if !c.isTest || *update {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return errors.Wrap(err, "failed to write output file")
+		}
+		return nil
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.Mark(errors.Newf("golden file %s does not exist, run the tests with -update to create it", path), ErrGoldenMismatch)
+	} else if err != nil {
+		return errors.Wrap(err, "failed to read golden file")
+	}
+
+	if bytes.Equal(want, data) {
+		return nil
+	}
+
+	diff := describe(want)
+	if diff == "" {
+		diff = fmt.Sprintf("golden is %d bytes, output is %d bytes", len(want), len(data))
+	}
+
+	return errors.Mark(errors.Newf("output differs from golden file %s, run the tests with -update to accept it:\n%s", path, diff), ErrGoldenMismatch)
+}
+
+// describeImageDiff describes how two images differ, or returns an empty string if they are the same
+func describeImageDiff(want, got image.Image) string {
+	if want.Bounds() != got.Bounds() {
+		return fmt.Sprintf("golden is %v, output is %v", want.Bounds(), got.Bounds())
+	}
+
+	var diffs []string
+	count := 0
+	bounds := want.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			wr, wg, wb, wa := want.At(x, y).RGBA()
+			gr, gg, gb, ga := got.At(x, y).RGBA()
+			if wr == gr && wg == gg && wb == gb && wa == ga {
+				continue
+			}
+
+			count++
+			if len(diffs) < maxPixelDiffs {
+				diffs = append(diffs, fmt.Sprintf("(%d,%d) golden %v, output %v", x, y, want.At(x, y), got.At(x, y)))
+			}
+		}
+	}
+
+	if count == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d pixels differ, including %s", count, strings.Join(diffs, "; "))
+}
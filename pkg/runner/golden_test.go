@@ -0,0 +1,20 @@
+package runner
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeImageDiff(t *testing.T) {
+	want := image.NewGray(image.Rect(0, 0, 3, 3))
+	got := image.NewGray(image.Rect(0, 0, 3, 3))
+	assert.Empty(t, describeImageDiff(want, got), "identical images")
+
+	got.SetGray(1, 2, color.Gray{Y: 255})
+	assert.Equal(t, "1 pixels differ, including (1,2) golden {0}, output {255}", describeImageDiff(want, got))
+
+	assert.Equal(t, "golden is (0,0)-(3,3), output is (0,0)-(4,3)", describeImageDiff(want, image.NewGray(image.Rect(0, 0, 4, 3))))
+}
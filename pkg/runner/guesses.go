@@ -0,0 +1,174 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Verdict is the response given by Advent of Code when an answer is submitted
+type Verdict string
+
+const (
+	VerdictCorrect Verdict = "correct"  // The answer was accepted
+	VerdictTooHigh Verdict = "too-high" // The answer was rejected as being too high
+	VerdictTooLow  Verdict = "too-low"  // The answer was rejected as being too low
+	VerdictWrong   Verdict = "wrong"    // The answer was rejected, without a hint
+)
+
+// ParseVerdict converts the string into a [Verdict]
+func ParseVerdict(s string) (Verdict, error) {
+	switch v := Verdict(s); v {
+	case VerdictCorrect, VerdictTooHigh, VerdictTooLow, VerdictWrong:
+		return v, nil
+	default:
+		return "", errors.Newf("unknown verdict %q, expected one of correct, too-high, too-low or wrong", s)
+	}
+}
+
+// Guess is a single answer which was submitted to Advent of Code
+type Guess struct {
+	Answer  Answer    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// GuessLog is a persisted history of all the answers submitted for each day and part,
+// along with the verdict for each.
+//
+// The verdicts are used to derive the tightest known minimum and maximum for each part,
+// as well as rejecting answers which are already known to be wrong.
+//
+// On disk it is a JSON document keyed by day ("day01", or "2022/day01" for days
+// outside the [DefaultYear]), then by input name, then
+// by part ("part1" or "part2"), with each part holding a list of guesses.
+type GuessLog struct {
+	mu      sync.Mutex
+	guesses map[DayID]map[string]map[string][]Guess // day -> input -> part -> guesses
+}
+
+// DefaultGuessLogFile returns the path of the guess log in the root of the repo
+func DefaultGuessLogFile() string {
+	return filepath.Join(repoDir, "guesses.json")
+}
+
+// LoadGuessLog reads the guess log at the given path
+//
+// If the file does not exist, an empty log is returned.
+func LoadGuessLog(path string) (*GuessLog, error) {
+	l := &GuessLog{guesses: make(map[DayID]map[string]map[string][]Guess)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read guess log")
+	}
+
+	var raw map[string]map[string]map[string][]Guess
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse guess log %s", path)
+	}
+
+	for key, inputs := range raw {
+		day, err := ParseDayID(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid day in guess log %s", path)
+		}
+
+		l.guesses[day] = inputs
+	}
+
+	return l, nil
+}
+
+// Save writes the guess log to the given path
+func (l *GuessLog) Save(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	raw := make(map[string]map[string]map[string][]Guess, len(l.guesses))
+	for day, inputs := range l.guesses {
+		raw[day.String()] = inputs
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode guess log")
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "failed to write guess log")
+	}
+
+	return nil
+}
+
+// Record adds a guess for the given day, input and part to the log
+func (l *GuessLog) Record(day DayID, input string, part int, answer Answer, verdict Verdict) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	inputs, found := l.guesses[day]
+	if !found {
+		inputs = make(map[string]map[string][]Guess)
+		l.guesses[day] = inputs
+	}
+
+	parts, found := inputs[input]
+	if !found {
+		parts = make(map[string][]Guess)
+		inputs[input] = parts
+	}
+
+	key := fmt.Sprintf("part%d", part)
+	parts[key] = append(parts[key], Guess{Answer: answer, Verdict: verdict, Time: time.Now().UTC()})
+}
+
+// applyTo tightens the known answers for the given day and input using the verdicts
+// of previous guesses
+func (l *GuessLog) applyTo(day DayID, input string, known *inputAnswers) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	parts := l.guesses[day][input]
+	for _, guess := range parts["part1"] {
+		known.part1.applyGuess(guess)
+	}
+	for _, guess := range parts["part2"] {
+		known.part2.applyGuess(guess)
+	}
+}
+
+// applyGuess tightens the answers using the verdict of the guess
+func (a *answers) applyGuess(guess Guess) {
+	switch guess.Verdict {
+	case VerdictCorrect:
+		if a.answer == nil {
+			a.answer = ptr(guess.Answer)
+		}
+	case VerdictTooHigh:
+		if a.max == nil {
+			a.max = ptr(guess.Answer)
+		} else if cmp, ok := guess.Answer.Cmp(*a.max); ok && cmp < 0 {
+			a.max = ptr(guess.Answer)
+		}
+	case VerdictTooLow:
+		if a.min == nil {
+			a.min = ptr(guess.Answer)
+		} else if cmp, ok := guess.Answer.Cmp(*a.min); ok && cmp > 0 {
+			a.min = ptr(guess.Answer)
+		}
+	case VerdictWrong:
+		a.wrong = append(a.wrong, guess.Answer)
+	}
+}
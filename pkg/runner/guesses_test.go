@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswers_ApplyGuess(t *testing.T) {
+	guess := func(answer int, verdict Verdict) Guess {
+		return Guess{Answer: AnswerOf(answer), Verdict: verdict}
+	}
+
+	tests := []struct {
+		name    string
+		guesses []Guess
+		min     string // The expected minimum, empty if unset
+		max     string // The expected maximum, empty if unset
+		answer  string // The expected answer, empty if unset
+		wrong   []string
+	}{
+		{
+			name:    "too high sets the maximum",
+			guesses: []Guess{guess(100, VerdictTooHigh)},
+			max:     "100",
+		},
+		{
+			name:    "lower too high tightens the maximum",
+			guesses: []Guess{guess(100, VerdictTooHigh), guess(50, VerdictTooHigh)},
+			max:     "50",
+		},
+		{
+			name:    "higher too high keeps the maximum",
+			guesses: []Guess{guess(50, VerdictTooHigh), guess(100, VerdictTooHigh)},
+			max:     "50",
+		},
+		{
+			name:    "too low sets the minimum",
+			guesses: []Guess{guess(10, VerdictTooLow)},
+			min:     "10",
+		},
+		{
+			name:    "higher too low tightens the minimum",
+			guesses: []Guess{guess(10, VerdictTooLow), guess(20, VerdictTooLow)},
+			min:     "20",
+		},
+		{
+			name:    "lower too low keeps the minimum",
+			guesses: []Guess{guess(20, VerdictTooLow), guess(10, VerdictTooLow)},
+			min:     "20",
+		},
+		{
+			name:    "bounds from both sides",
+			guesses: []Guess{guess(10, VerdictTooLow), guess(100, VerdictTooHigh), guess(20, VerdictTooLow), guess(90, VerdictTooHigh)},
+			min:     "20",
+			max:     "90",
+		},
+		{
+			name:    "conflicting bounds are both kept",
+			guesses: []Guess{guess(100, VerdictTooLow), guess(50, VerdictTooHigh)},
+			min:     "100",
+			max:     "50",
+		},
+		{
+			name:    "repeated guesses",
+			guesses: []Guess{guess(50, VerdictTooHigh), guess(50, VerdictTooHigh), guess(7, VerdictWrong), guess(7, VerdictWrong)},
+			max:     "50",
+			wrong:   []string{"7", "7"},
+		},
+		{
+			name:    "wrong guesses are collected",
+			guesses: []Guess{guess(7, VerdictWrong), guess(8, VerdictWrong)},
+			wrong:   []string{"7", "8"},
+		},
+		{
+			name:    "first correct guess is kept",
+			guesses: []Guess{guess(42, VerdictCorrect), guess(43, VerdictCorrect)},
+			answer:  "42",
+		},
+	}
+
+	str := func(a *Answer) string {
+		if a == nil {
+			return ""
+		}
+		return a.String()
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var a answers
+			for _, g := range test.guesses {
+				a.applyGuess(g)
+			}
+
+			assert.Equal(t, test.min, str(a.min), "min")
+			assert.Equal(t, test.max, str(a.max), "max")
+			assert.Equal(t, test.answer, str(a.answer), "answer")
+
+			var wrong []string
+			for _, w := range a.wrong {
+				wrong = append(wrong, w.String())
+			}
+			assert.Equal(t, test.wrong, wrong, "wrong")
+		})
+	}
+}
+
+func TestGuessLog_Record(t *testing.T) {
+	day := DayID{Year: 1999, Day: 1}
+	path := filepath.Join(t.TempDir(), "guesses.json")
+
+	guesses, err := LoadGuessLog(path)
+	require.NoError(t, err)
+	guesses.Record(day, "input", 1, AnswerOf(9), VerdictWrong)
+	guesses.Record(day, "input", 1, AnswerOf(5), VerdictTooLow)
+	guesses.Record(day, "input", 2, AnswerOf(100), VerdictTooHigh)
+	guesses.Record(day, "input", 2, AnswerOf(10), VerdictTooHigh)
+	guesses.Record(day, "other", 2, AnswerOf(1), VerdictTooHigh)
+
+	// The log should survive being saved and loaded again
+	require.NoError(t, guesses.Save(path))
+	guesses, err = LoadGuessLog(path)
+	require.NoError(t, err)
+
+	d := newTestDay(
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n * n, nil
+		},
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n + n, nil
+		},
+	)
+
+	inputPath := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(inputPath, []byte("3\n"), 0644))
+
+	results := d.Run(context.Background(), RunOptions{InputOptions: InputOptions{File: inputPath}, Guesses: guesses})
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	require.Len(t, results[0].Parts, 2)
+
+	// 9 is above the minimum of 5, but was already guessed and known to be wrong
+	assert.Equal(t, StatusWrong, results[0].Parts[0].Status)
+
+	// 6 is below the tightest maximum of 10, and the guess for the other input is ignored
+	assert.Equal(t, StatusOK, results[0].Parts[1].Status)
+
+	guesses.Record(day, "input", 2, AnswerOf(6), VerdictTooHigh)
+	results = d.Run(context.Background(), RunOptions{InputOptions: InputOptions{File: inputPath}, Guesses: guesses})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusTooHigh, results[0].Parts[1].Status)
+}
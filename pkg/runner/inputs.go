@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultInput is the name of the input read from inputs/dayNN.txt
+const DefaultInput = "default"
+
+// StdinInput is the name given to an input read from stdin
+const StdinInput = "stdin"
+
+// InputOptions controls where the inputs for a day are read from
+type InputOptions struct {
+	// File is a single input file to use instead of discovering inputs.
+	// If it is "-" the input is read from stdin.
+	//
+	// The input will be named after the file without its extension.
+	File string
+
+	// Dir is the directory to discover inputs in, if not set the
+	// inputs directory in the root of the repo is used.
+	//
+	// Within this directory, dayNN.txt is the [DefaultInput] for the day,
+	// and any dayNN/*.txt files are named inputs. Days outside the
+	// [DefaultYear] read from a subdirectory named after the year.
+	Dir string
+}
+
+// namedInput is a single input for a day
+type namedInput struct {
+	name string // The name of the input, used to look up expected answers
+	path string // Where the input was read from
+	data []byte // The contents of the input, nil if it has not been read yet
+}
+
+// open returns a reader for the input, reading from its file if it has not been read into memory
+func (i namedInput) open() (io.ReadCloser, error) {
+	if i.data != nil {
+		return io.NopCloser(bytes.NewReader(i.data)), nil
+	}
+
+	f, err := os.Open(i.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open input file")
+	}
+
+	return f, nil
+}
+
+// readInputs returns all the inputs for the given day, with their contents read into memory
+func (o InputOptions) readInputs(id DayID) ([]namedInput, error) {
+	inputs, err := o.findInputs(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range inputs {
+		if inputs[i].data != nil {
+			continue
+		}
+
+		if inputs[i].data, err = os.ReadFile(inputs[i].path); err != nil {
+			return nil, errors.Wrap(err, "failed to read input file")
+		}
+	}
+
+	return inputs, nil
+}
+
+// findInputs returns all the inputs for the given day without reading them, other
+// than stdin which is read into memory as it can only be read once
+func (o InputOptions) findInputs(id DayID) ([]namedInput, error) {
+	switch o.File {
+	case "":
+		// discover the inputs below
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read input from stdin")
+		}
+		return []namedInput{{name: StdinInput, path: "-", data: data}}, nil
+	default:
+		name := strings.TrimSuffix(filepath.Base(o.File), filepath.Ext(o.File))
+		return []namedInput{{name: name, path: o.File}}, nil
+	}
+
+	dir := id.InputDir(o.Dir)
+
+	// The default input
+	var paths []string
+	defaultFile := filepath.Join(dir, fmt.Sprintf("day%02d.txt", id.Day))
+	if _, err := os.Stat(defaultFile); err == nil {
+		paths = append(paths, defaultFile)
+	}
+
+	// Any named inputs
+	named, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("day%02d", id.Day), "*.txt"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search for named inputs")
+	}
+	slices.Sort(named)
+	paths = append(paths, named...)
+
+	if len(paths) == 0 {
+		return nil, errors.Newf("no inputs found for day %d of %d in %s", id.Day, id.Year, dir)
+	}
+
+	inputs := make([]namedInput, 0, len(paths))
+	for _, path := range paths {
+		name := DefaultInput
+		if path != defaultFile {
+			name = strings.TrimSuffix(filepath.Base(path), ".txt")
+		}
+
+		inputs = append(inputs, namedInput{name: name, path: path})
+	}
+
+	return inputs, nil
+}
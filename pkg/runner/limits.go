@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"context"
+	"runtime"
+	"runtime/metrics"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultTestTimeout is the timeout given to each part when run as a test,
+// unless the day sets its own using [Day.WithTimeout]
+const DefaultTestTimeout = 5 * time.Second
+
+const (
+	// cancelGracePeriod is how long a part is given to return once its context
+	// has been cancelled, before the runner stops waiting for it
+	cancelGracePeriod = 100 * time.Millisecond
+
+	// heapSampleInterval is how often the live heap is checked against the heap budget
+	heapSampleInterval = 10 * time.Millisecond
+
+	// liveHeapMetric is the runtime metric used to measure the live heap, as marked by the
+	// last GC cycle so unswept garbage isn't counted against the budget
+	liveHeapMetric = "/gc/heap/live:bytes"
+)
+
+var (
+	// ErrTimedOut is the cause of a part's context being cancelled when it runs past its timeout
+	ErrTimedOut = errors.New("part timed out")
+
+	// ErrHeapBudgetExceeded is the cause of a part's context being cancelled when the live heap
+	// grows by more than its heap budget
+	ErrHeapBudgetExceeded = errors.New("part exceeded its heap budget")
+)
+
+// Limits controls the resources a single part can use while running
+type Limits struct {
+	Timeout    time.Duration // How long the part can run for (zero for no limit)
+	HeapBudget uint64        // How many bytes the live heap can grow by while the part runs (zero for no limit)
+}
+
+// orDefault returns the limits, with any which are unset taken from the defaults
+func (l Limits) orDefault(defaults Limits) Limits {
+	if l.Timeout == 0 {
+		l.Timeout = defaults.Timeout
+	}
+	if l.HeapBudget == 0 {
+		l.HeapBudget = defaults.HeapBudget
+	}
+
+	return l
+}
+
+// runLimited runs the part under the given limits
+//
+// If a limit is hit, the context given to the part is cancelled with either [ErrTimedOut]
+// or [ErrHeapBudgetExceeded] as the cause, and that cause is returned as the error. Parts
+// which poll their context will return cleanly, however if a part does not return within
+// a short grace period it is abandoned and left running in the background, in which case
+// abandoned will be true. A part which returns an answer without an error as the limit is
+// hit keeps its answer.
+//
+// The heap budget is measured across the whole process, so it is only reliable when a
+// single part runs at a time.
+func runLimited(parent context.Context, limits Limits, run func(ctx context.Context) (Answer, error)) (answer Answer, abandoned bool, err error) {
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+
+	if limits.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, limits.Timeout, errors.Wrapf(ErrTimedOut, "after %s", limits.Timeout))
+		defer cancelTimeout()
+	}
+
+	if limits.HeapBudget > 0 {
+		// The live heap is only measured by each GC cycle, so collect now to measure it
+		// from what is live as the part starts
+		runtime.GC()
+		go watchHeap(ctx, liveHeap(), limits.HeapBudget, cancel)
+	}
+
+	type result struct {
+		answer Answer
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		answer, err := run(ctx)
+		done <- result{answer, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && ctx.Err() != nil {
+			// The part noticed it was cancelled
+			return r.answer, false, context.Cause(ctx)
+		}
+		return r.answer, false, r.err
+
+	case <-ctx.Done():
+	}
+
+	select {
+	case r := <-done:
+		if r.err == nil {
+			// The part finished as the limit was hit
+			return r.answer, false, nil
+		}
+		return Answer{}, false, context.Cause(ctx)
+	case <-time.After(cancelGracePeriod):
+		return Answer{}, true, context.Cause(ctx)
+	}
+}
+
+// watchHeap cancels the context if the live heap grows by more than the budget from its start
+func watchHeap(ctx context.Context, start, budget uint64, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(heapSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if used := liveHeap(); used > start && used-start > budget {
+			cancel(errors.Wrapf(ErrHeapBudgetExceeded, "live heap grew by %d bytes, budget was %d bytes", used-start, budget))
+			return
+		}
+	}
+}
+
+// liveHeap returns the size of the live heap as of the last GC cycle
+func liveHeap() uint64 {
+	sample := []metrics.Sample{{Name: liveHeapMetric}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
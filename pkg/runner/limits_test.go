@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLimited_Timeout(t *testing.T) {
+	t.Run("polls context", func(t *testing.T) {
+		_, abandoned, err := runLimited(context.Background(), Limits{Timeout: 10 * time.Millisecond}, func(ctx context.Context) (Answer, error) {
+			<-ctx.Done()
+			return Answer{}, ctx.Err()
+		})
+
+		assert.ErrorIs(t, err, ErrTimedOut)
+		assert.False(t, abandoned, "part should have exited cleanly")
+	})
+
+	t.Run("ignores context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		_, abandoned, err := runLimited(context.Background(), Limits{Timeout: 10 * time.Millisecond}, func(ctx context.Context) (Answer, error) {
+			<-release
+			return AnswerOf(1), nil
+		})
+
+		assert.ErrorIs(t, err, ErrTimedOut)
+		assert.True(t, abandoned, "part should have been abandoned")
+	})
+
+	t.Run("finishes as the limit is hit", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		defer cancelParent()
+
+		answer, abandoned, err := runLimited(parent, Limits{Timeout: time.Second}, func(ctx context.Context) (Answer, error) {
+			cancelParent()
+			<-ctx.Done()
+			return AnswerOf(42), nil
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, abandoned)
+		assert.Equal(t, "42", answer.String())
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		answer, abandoned, err := runLimited(context.Background(), Limits{Timeout: time.Second}, func(ctx context.Context) (Answer, error) {
+			return AnswerOf(42), nil
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, abandoned)
+		assert.Equal(t, "42", answer.String())
+	})
+}
+
+func TestRunLimited_HeapBudget(t *testing.T) {
+	var retained [][]byte
+	_, _, err := runLimited(context.Background(), Limits{HeapBudget: 1 << 20}, func(ctx context.Context) (Answer, error) {
+		for ctx.Err() == nil {
+			retained = append(retained, make([]byte, 64<<10))
+			time.Sleep(time.Millisecond)
+		}
+		return Answer{}, ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, ErrHeapBudgetExceeded)
+	assert.NotEmpty(t, retained)
+}
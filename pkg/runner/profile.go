@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Profiles is the set of profiles to record around each part when running a day
+//
+// The profiles are written using [Context.OutputFile], so are named after the day and part.
+// Profiles of named inputs also include the input name, e.g. dayNN_partNN.name.cpu.pprof.
+type Profiles struct {
+	CPU    bool // Record a CPU profile to dayNN_partNN.cpu.pprof
+	Memory bool // Record a heap profile to dayNN_partNN.mem.pprof once the part has finished
+	Trace  bool // Record an execution trace to dayNN_partNN.trace
+}
+
+// ParseProfiles converts a list of profile kinds ("cpu", "mem" or "trace") into [Profiles]
+func ParseProfiles(kinds []string) (p Profiles, err error) {
+	for _, kind := range kinds {
+		switch kind {
+		case "cpu":
+			p.CPU = true
+		case "mem":
+			p.Memory = true
+		case "trace":
+			p.Trace = true
+		default:
+			return Profiles{}, errors.Newf("unknown profile %q, expected one of cpu, mem or trace", kind)
+		}
+	}
+
+	return p, nil
+}
+
+// Any returns true if any profile is enabled
+func (p Profiles) Any() bool {
+	return p.CPU || p.Memory || p.Trace
+}
+
+// start begins recording the profiles for the part running against the named input,
+// returning a function which stops them and writes the results
+//
+// If starting any of the profiles fails, any already started are stopped.
+func (p Profiles) start(ctx *Context, input string) (stop func() error, err error) {
+	outputFile := func(ext string) string {
+		if input != "" && input != DefaultInput {
+			ext = input + "." + ext
+		}
+		return ctx.OutputFile(ext)
+	}
+
+	var stops []func() error
+	stopAll := func() error {
+		var errs []error
+		for _, stop := range stops {
+			errs = append(errs, stop())
+		}
+		return errors.Join(errs...)
+	}
+
+	if p.CPU {
+		f, err := os.Create(outputFile("cpu.pprof"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create cpu profile")
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, errors.Wrap(err, "failed to start cpu profile")
+		}
+
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return errors.Wrap(f.Close(), "failed to write cpu profile")
+		})
+	}
+
+	if p.Trace {
+		f, err := os.Create(outputFile("trace"))
+		if err != nil {
+			return nil, errors.CombineErrors(errors.Wrap(err, "failed to create trace"), stopAll())
+		}
+
+		if err := trace.Start(f); err != nil {
+			_ = f.Close()
+			return nil, errors.CombineErrors(errors.Wrap(err, "failed to start trace"), stopAll())
+		}
+
+		stops = append(stops, func() error {
+			trace.Stop()
+			return errors.Wrap(f.Close(), "failed to write trace")
+		})
+	}
+
+	if p.Memory {
+		path := outputFile("mem.pprof")
+
+		stops = append(stops, func() error {
+			f, err := os.Create(path)
+			if err != nil {
+				return errors.Wrap(err, "failed to create memory profile")
+			}
+			defer func() { _ = f.Close() }()
+
+			runtime.GC() // get up-to-date statistics
+			return errors.Wrap(pprof.WriteHeapProfile(f), "failed to write memory profile")
+		})
+	}
+
+	return stopAll, nil
+}
@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// liveProgressInterval is the minimum time between redraws of the live progress line
+	liveProgressInterval = 100 * time.Millisecond
+
+	// logProgressInterval is the minimum time between progress log events when not running interactively
+	logProgressInterval = 5 * time.Second
+
+	// progressBarWidth is the number of characters used to draw the progress bar
+	progressBarWidth = 30
+)
+
+// progressReporter renders the progress of a single part
+//
+// When live, progress is drawn as a single line which is redrawn in place,
+// otherwise it is written as log events. Both are throttled so that parts can
+// report progress from inside hot loops.
+type progressReporter struct {
+	mu       sync.Mutex
+	log      zerolog.Logger
+	live     io.Writer // If set, where to draw the live progress line
+	stage    string    // The current stage of the part
+	last     time.Time // When progress was last drawn or logged
+	drawn    bool      // Is the live progress line currently drawn
+	finished bool      // Has the part finished running
+}
+
+// newProgressReporter creates a reporter which draws a live progress line to
+// the given writer, or logs progress if live is nil
+func newProgressReporter(log zerolog.Logger, live io.Writer) *progressReporter {
+	return &progressReporter{log: log, live: live}
+}
+
+// Progress reports that done out of total units of work have been completed
+// by the part.
+//
+// For interactive runs this is drawn as a progress line in the terminal,
+// otherwise it is periodically logged. It does nothing in tests.
+func (c *Context) Progress(done, total int) {
+	if c == nil || c.progress == nil {
+		return
+	}
+
+	c.progress.update(done, total)
+}
+
+// Stage reports that the part has moved onto a new named stage of work,
+// any progress reported after this is for that stage.
+//
+// It does nothing in tests.
+func (c *Context) Stage(name string) {
+	if c == nil || c.progress == nil {
+		return
+	}
+
+	c.progress.setStage(name)
+}
+
+// setStage records the new stage, which is always shown immediately
+func (p *progressReporter) setStage(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.finished {
+		return
+	}
+
+	p.stage = name
+	p.last = time.Now()
+
+	if p.live != nil {
+		p.draw(p.stage)
+	} else {
+		p.log.Info().Str("stage", name).Msg("part entered new stage")
+	}
+}
+
+// update records the progress, drawing or logging it if enough time has passed
+func (p *progressReporter) update(done, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	interval := logProgressInterval
+	if p.live != nil {
+		interval = liveProgressInterval
+	}
+
+	now := time.Now()
+	if p.finished || now.Sub(p.last) < interval {
+		return
+	}
+	p.last = now
+
+	percent := 0.0
+	if total > 0 {
+		percent = 100 * float64(done) / float64(total)
+	}
+
+	if p.live == nil {
+		event := p.log.Info().Int("done", done).Int("total", total).Str("percent", fmt.Sprintf("%.1f%%", percent))
+		if p.stage != "" {
+			event = event.Str("stage", p.stage)
+		}
+		event.Msg("part progress")
+		return
+	}
+
+	filled := min(max(int(percent/100*progressBarWidth), 0), progressBarWidth)
+	line := fmt.Sprintf("[%s%s] %5.1f%% (%d/%d)", strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled), percent, done, total)
+	if p.stage != "" {
+		line = p.stage + " " + line
+	}
+	p.draw(line)
+}
+
+// draw replaces the live progress line with the given line
+func (p *progressReporter) draw(line string) {
+	_, _ = fmt.Fprintf(p.live, "\r\033[K%s", line)
+	p.drawn = true
+}
+
+// finish clears the live progress line and ignores any further progress
+// reported by the part
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.drawn {
+		_, _ = fmt.Fprint(p.live, "\r\033[K")
+		p.drawn = false
+	}
+	p.finished = true
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress_Live(t *testing.T) {
+	var out bytes.Buffer
+	ctx := &Context{progress: newProgressReporter(zerolog.Nop(), &out)}
+
+	ctx.Stage("sweeping")
+	ctx.Progress(5, 10)
+	assert.Equal(t, "\r\033[Ksweeping", out.String(), "progress should be throttled after a stage change")
+
+	ctx.progress.last = ctx.progress.last.Add(-liveProgressInterval)
+	ctx.Progress(5, 10)
+	assert.True(t, strings.HasSuffix(out.String(), "sweeping [===============               ]  50.0% (5/10)"), "got %q", out.String())
+
+	ctx.progress.finish()
+	assert.True(t, strings.HasSuffix(out.String(), "\r\033[K"), "finish should clear the line")
+
+	out.Reset()
+	ctx.Stage("after finishing")
+	assert.Empty(t, out.String(), "progress after finishing should be ignored")
+}
+
+func TestProgress_NoOp(t *testing.T) {
+	var ctx *Context
+	ctx.Stage("nil context")
+	ctx.Progress(1, 2)
+
+	(&Context{isTest: true}).Progress(1, 2)
+}
@@ -1,33 +1,219 @@
 package runner
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
 )
 
+// DefaultYear is the year of any day which isn't registered with [Day.WithYear]
+const DefaultYear = 2023
+
 var (
-	days = make(map[int]RunnableDay)
+	registered []RunnableDay // every day in the order they were created
+
+	registryOnce sync.Once
+	days         map[DayID]RunnableDay
+	registryErr  error
 )
 
 type RunnableDay interface {
+	// Year returns the year of the event the day is from
+	Year() int
+
 	// Day returns the day number
 	Day() int
 
-	// Run runs the day
-	Run(ctx context.Context, saveOutput bool)
+	// Run runs the day against each of its inputs, returning the result of each
+	//
+	// The day will log using the logger attached to the context
+	// (see [zerolog.Logger.WithContext]) or the global logger if none
+	// is attached.
+	Run(ctx context.Context, opts RunOptions) []DayResult
+
+	// Bench repeatedly runs the days parser and parts, returning timing statistics
+	Bench(ctx context.Context, opts BenchOptions) []BenchResult
+
+	// Verify checks the days parts against their reference implementations, if it has any
+	Verify(ctx context.Context, opts VerifyOptions) VerifyResult
+
+	// Generate creates a random input for the day, if it has a generator
+	Generate(rng *rand.Rand, size int) ([]byte, error)
+}
+
+// DayID identifies a day of a specific year
+type DayID struct {
+	Year int
+	Day  int
+}
+
+// String returns the ID as "dayNN" for days in the [DefaultYear], or "YYYY/dayNN" for any other year
+//
+// This is used as the key for the day in the answers file, guess log and benchmark baselines.
+func (id DayID) String() string {
+	if id.Year == DefaultYear {
+		return fmt.Sprintf("day%02d", id.Day)
+	}
+
+	return fmt.Sprintf("%d/day%02d", id.Year, id.Day)
+}
+
+// dir returns the directory within base which holds the files for the days year
+//
+// Days in the [DefaultYear] use base directly, other years use a subdirectory named after the year.
+func (id DayID) dir(base string) string {
+	if id.Year == DefaultYear {
+		return base
+	}
+
+	return filepath.Join(base, strconv.Itoa(id.Year))
+}
+
+// PackageDir returns the directory of the days package, internal/dayNN for days in the
+// [DefaultYear] and internal/YYYY/dayNN for other years
+func (id DayID) PackageDir() string {
+	return filepath.Join(id.dir(filepath.Join(repoDir, "internal")), fmt.Sprintf("day%02d", id.Day))
+}
+
+// InputDir returns the directory the days inputs are read from, within the given
+// inputs directory or the inputs directory in the root of the repo if dir is empty
+//
+// See [InputOptions] for how the inputs within the directory are named.
+func (id DayID) InputDir(dir string) string {
+	if dir == "" {
+		dir = filepath.Join(repoDir, "inputs")
+	}
+
+	return id.dir(dir)
+}
+
+// ParseDayID parses an ID in the format returned by [DayID.String]
+func ParseDayID(s string) (DayID, error) {
+	id := DayID{Year: DefaultYear}
+
+	dayStr := s
+	if yearStr, rest, found := strings.Cut(s, "/"); found {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return DayID{}, errors.Newf("invalid year in day %q", s)
+		}
+		id.Year = year
+		dayStr = rest
+	}
+
+	if _, err := fmt.Sscanf(dayStr, "day%d", &id.Day); err != nil {
+		return DayID{}, errors.Newf("invalid day %q", s)
+	}
+
+	return id, nil
+}
+
+// IDOf returns the ID of the given day
+func IDOf(day RunnableDay) DayID {
+	return DayID{Year: day.Year(), Day: day.Day()}
+}
+
+// register adds the day to the registry
+//
+// The registry is only built the first time [AllDays] is called, so that the
+// year of the day can be set using [Day.WithYear] after it has been created.
+func register(d RunnableDay) {
+	registered = append(registered, d)
+}
+
+// buildRegistry indexes all the registered days by their ID, returning an error
+// if the same day has been registered twice, as the second registration would
+// otherwise silently replace the first.
+func buildRegistry() {
+	days = make(map[DayID]RunnableDay, len(registered))
+
+	var errs []error
+	for _, day := range registered {
+		id := IDOf(day)
+		if _, found := days[id]; found {
+			errs = append(errs, errors.Newf("day %d of %d has been registered more than once", id.Day, id.Year))
+			continue
+		}
+
+		days[id] = day
+	}
+
+	registryErr = errors.Join(errs...)
+	checkRegistrations()
 }
 
-// AllDays returns all the days in order
-func AllDays() []RunnableDay {
+// AllDays returns all the days in order of year and then day number
+//
+// If any years are given, only the days from those years are returned.
+//
+// The first time it is called, a warning is logged for any day which has a package
+// in the internal directory but has not been registered, which normally means
+// cmd/aoc2023/days.go needs regenerating.
+func AllDays(years ...int) ([]RunnableDay, error) {
+	registryOnce.Do(buildRegistry)
+	if registryErr != nil {
+		return nil, registryErr
+	}
+
 	var daysSlice []RunnableDay
 
-	for _, day := range days {
-		daysSlice = append(daysSlice, day)
+	for id, day := range days {
+		if len(years) == 0 || slices.Contains(years, id.Year) {
+			daysSlice = append(daysSlice, day)
+		}
 	}
 
 	slices.SortFunc(daysSlice, func(a, b RunnableDay) int {
-		return a.Day() - b.Day()
+		if a.Year() != b.Year() {
+			return cmp.Compare(a.Year(), b.Year())
+		}
+
+		return cmp.Compare(a.Day(), b.Day())
 	})
 
-	return daysSlice
+	return daysSlice, nil
+}
+
+// checkRegistrations warns about any day package which has not been registered
+//
+// Days in the [DefaultYear] are expected at internal/dayNN, and other years at internal/YYYY/dayNN.
+func checkRegistrations() {
+	packages, err := filepath.Glob(filepath.Join(repoDir, "internal", "day[0-9][0-9]"))
+	if err != nil {
+		return
+	}
+	otherYears, err := filepath.Glob(filepath.Join(repoDir, "internal", "[0-9][0-9][0-9][0-9]", "day[0-9][0-9]"))
+	if err != nil {
+		return
+	}
+
+	for _, pkgDir := range append(packages, otherYears...) {
+		if info, err := os.Stat(pkgDir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		rel, err := filepath.Rel(filepath.Join(repoDir, "internal"), pkgDir)
+		if err != nil {
+			continue
+		}
+
+		id, err := ParseDayID(filepath.ToSlash(rel))
+		if err != nil || id.Day < 1 || id.Day > 25 {
+			continue
+		}
+
+		if _, found := days[id]; !found {
+			log.Warn().Int("year", id.Year).Int("day", id.Day).Str("package", pkgDir).Msg("day has a package but is not registered, run go generate ./cmd/aoc2023")
+		}
+	}
 }
@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayID_RoundTrip(t *testing.T) {
+	tests := []struct {
+		id  DayID
+		str string
+	}{
+		{DayID{Year: DefaultYear, Day: 1}, "day01"},
+		{DayID{Year: DefaultYear, Day: 25}, "day25"},
+		{DayID{Year: 2022, Day: 7}, "2022/day07"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.str, test.id.String())
+
+		id, err := ParseDayID(test.str)
+		assert.NoError(t, err)
+		assert.Equal(t, test.id, id)
+	}
+
+	for _, invalid := range []string{"", "dayX", "year/day01", "2022/"} {
+		_, err := ParseDayID(invalid)
+		assert.Error(t, err, "parsing %q", invalid)
+	}
+}
@@ -0,0 +1,282 @@
+package runner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// jsonReport is the top level of the JSON report written by [WriteJSONReport]
+type jsonReport struct {
+	DurationNS int64           `json:"duration_ns"`
+	Summary    jsonSummary     `json:"summary"`
+	Days       []jsonDayReport `json:"days"`
+}
+
+type jsonSummary struct {
+	Days           int `json:"days"`
+	FailedDays     int `json:"failed_days"`
+	Parts          int `json:"parts"`
+	Passed         int `json:"passed"`
+	Failed         int `json:"failed"`
+	NotImplemented int `json:"not_implemented"`
+}
+
+type jsonDayReport struct {
+	Year            int              `json:"year"`
+	Day             int              `json:"day"`
+	Input           string           `json:"input,omitempty"`
+	ParseDurationNS int64            `json:"parse_duration_ns"`
+	Error           string           `json:"error,omitempty"`
+	Parts           []jsonPartReport `json:"parts"`
+}
+
+type jsonPartReport struct {
+	Part       int     `json:"part"`
+	Status     Status  `json:"status"`
+	Answer     *Answer `json:"answer,omitempty"`
+	Expected   *Answer `json:"expected,omitempty"`
+	DurationNS int64   `json:"duration_ns"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// WriteJSONReport writes the results as a JSON document to w
+func WriteJSONReport(w io.Writer, results []DayResult, duration time.Duration) error {
+	summary := Summarise(results)
+
+	report := jsonReport{
+		DurationNS: duration.Nanoseconds(),
+		Summary:    jsonSummary(summary),
+		Days:       make([]jsonDayReport, 0, len(results)),
+	}
+
+	for _, result := range results {
+		day := jsonDayReport{
+			Year:            result.Year,
+			Day:             result.Day,
+			Input:           result.Input,
+			ParseDurationNS: result.ParseDuration.Nanoseconds(),
+			Parts:           make([]jsonPartReport, 0, len(result.Parts)),
+		}
+		if result.Err != nil {
+			day.Error = result.Err.Error()
+		}
+
+		for _, part := range result.Parts {
+			partReport := jsonPartReport{
+				Part:       part.Part,
+				Status:     part.Status,
+				Expected:   part.Expected,
+				DurationNS: part.Duration.Nanoseconds(),
+			}
+
+			if part.Status != StatusNotImplemented && part.Err == nil {
+				partReport.Answer = ptr(part.Answer)
+			}
+
+			if part.Err != nil {
+				partReport.Error = part.Err.Error()
+			}
+
+			day.Parts = append(day.Parts, partReport)
+		}
+
+		report.Days = append(report.Days, day)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return errors.Wrap(err, "failed to encode json report")
+	}
+
+	return nil
+}
+
+// ReadJSONReport reads a report written by [WriteJSONReport], returning the results
+// and how long the run took
+//
+// Any errors in the report are returned as plain errors containing the original message.
+func ReadJSONReport(r io.Reader) (results []DayResult, duration time.Duration, err error) {
+	var report jsonReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to decode json report")
+	}
+
+	for _, day := range report.Days {
+		result := DayResult{
+			Year:          day.Year,
+			Day:           day.Day,
+			Input:         day.Input,
+			ParseDuration: time.Duration(day.ParseDurationNS),
+			Parts:         make([]PartResult, 0, len(day.Parts)),
+		}
+		if day.Error != "" {
+			result.Err = errors.New(day.Error)
+		}
+
+		for _, part := range day.Parts {
+			partResult := PartResult{
+				Part:     part.Part,
+				Expected: part.Expected,
+				Duration: time.Duration(part.DurationNS),
+				Status:   part.Status,
+			}
+			if part.Answer != nil {
+				partResult.Answer = *part.Answer
+			}
+			if part.Error != "" {
+				partResult.Err = errors.New(part.Error)
+			}
+
+			result.Parts = append(result.Parts, partResult)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, time.Duration(report.DurationNS), nil
+}
+
+// junitTestSuites is the top level of the JUnit report written by [WriteJUnitReport]
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// WriteJUnitReport writes the results as a JUnit XML document to w
+//
+// Each day and input is written as a test suite (named "dayNN", or "YYYY.dayNN" for days outside the
+// [DefaultYear]), with each part of that day being a test case.
+// If the days input could not be read or parsed, a single "input" test case is written
+// with the error.
+func WriteJUnitReport(w io.Writer, results []DayResult, duration time.Duration) error {
+	report := junitTestSuites{
+		Name: "aoc2023",
+		Time: junitSeconds(duration),
+	}
+
+	for _, result := range results {
+		className := strings.ReplaceAll(result.ID().String(), "/", ".")
+		if result.Input != "" && result.Input != DefaultInput {
+			className = fmt.Sprintf("%s.%s", className, result.Input)
+		}
+		suite := junitTestSuite{Name: className}
+		suiteDuration := result.ParseDuration
+
+		if result.Err != nil {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "input",
+				ClassName: className,
+				Time:      junitSeconds(result.ParseDuration),
+				Error:     &junitMessage{Message: result.Err.Error(), Type: string(StatusError)},
+			})
+			suite.Errors++
+		}
+
+		for _, part := range result.Parts {
+			suiteDuration += part.Duration
+
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("part%d", part.Part),
+				ClassName: className,
+				Time:      junitSeconds(part.Duration),
+			}
+
+			switch part.Status {
+			case StatusOK:
+				testCase.SystemOut = fmt.Sprintf("answer: %s", part.Answer)
+			case StatusNotImplemented:
+				testCase.Skipped = &junitMessage{Message: "part not implemented"}
+				suite.Skipped++
+			case StatusError:
+				testCase.Error = &junitMessage{Message: part.Err.Error(), Type: string(part.Status)}
+				suite.Errors++
+			default:
+				testCase.Failure = &junitMessage{Message: failureMessage(part), Type: string(part.Status)}
+				suite.Failures++
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+		}
+
+		suite.Tests = len(suite.Cases)
+		suite.Time = junitSeconds(suiteDuration)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errors.Wrap(err, "failed to write xml header")
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return errors.Wrap(err, "failed to encode junit report")
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// failureMessage describes why a part failed
+func failureMessage(part PartResult) string {
+	switch {
+	case part.Err != nil:
+		return part.Err.Error()
+	case part.Status == StatusWrong && part.Expected != nil:
+		return fmt.Sprintf("wrong answer: got %s, expected %s", part.Answer, part.Expected)
+	case part.Status == StatusTooHigh:
+		return fmt.Sprintf("answer %s is above the hinted maximum", part.Answer)
+	case part.Status == StatusTooLow:
+		return fmt.Sprintf("answer %s is below the hinted minimum", part.Answer)
+	default:
+		return fmt.Sprintf("%s: got %s", part.Status, part.Answer)
+	}
+}
+
+// junitSeconds formats the duration as seconds for JUnit reports
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
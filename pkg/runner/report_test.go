@@ -0,0 +1,157 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reportResults covers every part status, a day whose input failed to parse, a named input
+// and a day outside the default year
+var reportResults = []DayResult{
+	{
+		Year:          DefaultYear,
+		Day:           1,
+		Input:         DefaultInput,
+		ParseDuration: time.Millisecond,
+		Parts: []PartResult{
+			{Part: 1, Status: StatusOK, Answer: AnswerOf(142), Expected: ptr(AnswerOf(142)), Duration: 2 * time.Millisecond},
+			{Part: 2, Status: StatusWrong, Answer: AnswerOf(280), Expected: ptr(AnswerOf(281)), Duration: 3 * time.Millisecond},
+		},
+	},
+	{
+		Year:  DefaultYear,
+		Day:   2,
+		Input: "alt",
+		Parts: []PartResult{
+			{Part: 1, Status: StatusTooHigh, Answer: AnswerOf(100)},
+			{Part: 2, Status: StatusTooLow, Answer: AnswerOf(1)},
+		},
+	},
+	{
+		Year:  2022,
+		Day:   3,
+		Input: DefaultInput,
+		Parts: []PartResult{
+			{Part: 1, Status: StatusError, Err: errors.New("bad input")},
+			{Part: 2, Status: StatusNotImplemented},
+		},
+	},
+	{
+		Year:  DefaultYear,
+		Day:   4,
+		Input: DefaultInput,
+		Parts: []PartResult{
+			{Part: 1, Status: StatusTimedOut, Err: errors.New("part timed out: after 1s"), Duration: time.Second},
+			{Part: 2, Status: StatusHeapBudgetExceeded, Err: errors.New("part exceeded its heap budget")},
+		},
+	},
+	{
+		Year:  DefaultYear,
+		Day:   5,
+		Input: DefaultInput,
+		Err:   errors.New("failed to parse input"),
+	},
+}
+
+func TestJSONReport_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONReport(&buf, reportResults, 5*time.Second))
+
+	results, duration, err := ReadJSONReport(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, duration)
+	require.Len(t, results, len(reportResults))
+
+	for i, want := range reportResults {
+		got := results[i]
+
+		assert.Equal(t, want.ID(), got.ID())
+		assert.Equal(t, want.Input, got.Input)
+		assert.Equal(t, want.ParseDuration, got.ParseDuration)
+		assert.Equal(t, errorMessage(want.Err), errorMessage(got.Err))
+		require.Len(t, got.Parts, len(want.Parts), "%s", want.ID())
+
+		for j, wantPart := range want.Parts {
+			gotPart := got.Parts[j]
+
+			assert.Equal(t, wantPart.Part, gotPart.Part)
+			assert.Equal(t, wantPart.Status, gotPart.Status, "%s part %d", want.ID(), wantPart.Part)
+			assert.True(t, wantPart.Answer.Equal(gotPart.Answer), "%s part %d answer: got %s, want %s", want.ID(), wantPart.Part, gotPart.Answer, wantPart.Answer)
+			assert.Equal(t, wantPart.Expected, gotPart.Expected, "%s part %d", want.ID(), wantPart.Part)
+			assert.Equal(t, wantPart.Duration, gotPart.Duration, "%s part %d", want.ID(), wantPart.Part)
+			assert.Equal(t, errorMessage(wantPart.Err), errorMessage(gotPart.Err), "%s part %d", want.ID(), wantPart.Part)
+		}
+	}
+
+	assert.Equal(t, Summarise(reportResults), Summarise(results))
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnitReport(&buf, reportResults, 5*time.Second))
+	require.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	assert.Equal(t, 9, report.Tests)
+	assert.Equal(t, 5, report.Failures)
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, "5.000000", report.Time)
+
+	cases := make(map[string]junitTestCase)
+	var suites []string
+	for _, suite := range report.Suites {
+		suites = append(suites, suite.Name)
+		for _, c := range suite.Cases {
+			cases[c.ClassName+"/"+c.Name] = c
+		}
+	}
+	assert.Equal(t, []string{"day01", "day02.alt", "2022.day03", "day04", "day05"}, suites)
+
+	tests := []struct {
+		name    string
+		failure *junitMessage
+		error   *junitMessage
+		skipped *junitMessage
+		out     string
+	}{
+		{name: "day01/part1", out: "answer: 142"},
+		{name: "day01/part2", failure: &junitMessage{Message: "wrong answer: got 280, expected 281", Type: "wrong"}},
+		{name: "day02.alt/part1", failure: &junitMessage{Message: "answer 100 is above the hinted maximum", Type: "too-high"}},
+		{name: "day02.alt/part2", failure: &junitMessage{Message: "answer 1 is below the hinted minimum", Type: "too-low"}},
+		{name: "2022.day03/part1", error: &junitMessage{Message: "bad input", Type: "error"}},
+		{name: "2022.day03/part2", skipped: &junitMessage{Message: "part not implemented"}},
+		{name: "day04/part1", failure: &junitMessage{Message: "part timed out: after 1s", Type: "timed-out"}},
+		{name: "day04/part2", failure: &junitMessage{Message: "part exceeded its heap budget", Type: "heap-budget-exceeded"}},
+		{name: "day05/input", error: &junitMessage{Message: "failed to parse input", Type: "error"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, found := cases[test.name]
+			require.True(t, found, "missing test case")
+
+			assert.Equal(t, test.failure, c.Failure, "failure")
+			assert.Equal(t, test.error, c.Error, "error")
+			assert.Equal(t, test.skipped, c.Skipped, "skipped")
+			assert.Equal(t, test.out, c.SystemOut, "system-out")
+		})
+	}
+}
+
+// errorMessage returns the message of the error, or an empty string if it is nil
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
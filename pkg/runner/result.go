@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"time"
+)
+
+// Status is the outcome of running a single part of a day
+type Status string
+
+const (
+	StatusOK                 Status = "ok"                   // The part returned an answer which passed all checks
+	StatusWrong              Status = "wrong"                // The part returned an answer which did not match the expected answer
+	StatusTooHigh            Status = "too-high"             // The part returned an answer above the hinted maximum
+	StatusTooLow             Status = "too-low"              // The part returned an answer below the hinted minimum
+	StatusNotImplemented     Status = "not-implemented"      // The part has not been implemented yet
+	StatusError              Status = "error"                // The part returned an error, or was unable to run
+	StatusTimedOut           Status = "timed-out"            // The part ran for longer than its timeout
+	StatusHeapBudgetExceeded Status = "heap-budget-exceeded" // The live heap grew by more than the parts heap budget
+)
+
+// Failed returns true if the status represents a failed part
+//
+// Parts which are not implemented are not considered failures.
+func (s Status) Failed() bool {
+	return s != StatusOK && s != StatusNotImplemented
+}
+
+// PartResult is the result of running a single part of a day
+type PartResult struct {
+	Part     int           // The part number
+	Answer   Answer        // The answer returned by the part
+	Expected *Answer       // The expected answer, if known
+	Duration time.Duration // How long the part took to run
+	Status   Status        // The outcome of running the part
+	Err      error         // The error returned by the part if Status is StatusError
+}
+
+// DayResult is the result of running a day against a single input
+type DayResult struct {
+	Year          int           // The year of the event
+	Day           int           // The day number
+	Input         string        // The name of the input which was used
+	ParseDuration time.Duration // How long it took to parse the input, excluding reading it (zero when streaming inputs)
+	Err           error         // Set if the input could not be read or parsed, in which case no parts were run
+	Parts         []PartResult  // The results of each part which was run
+}
+
+// ID returns the ID of the day the result is for
+func (r DayResult) ID() DayID {
+	return DayID{Year: r.Year, Day: r.Day}
+}
+
+// Failed returns true if the day could not be run, or any part of it failed
+func (r DayResult) Failed() bool {
+	if r.Err != nil {
+		return true
+	}
+
+	for _, part := range r.Parts {
+		if part.Status.Failed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summary is an aggregated view over the results of running multiple days
+type Summary struct {
+	Days           int // The number of days run
+	FailedDays     int // The number of days which failed
+	Parts          int // The number of parts run
+	Passed         int // The number of parts with StatusOK
+	Failed         int // The number of parts which failed
+	NotImplemented int // The number of parts which are not implemented
+}
+
+// Summarise aggregates the given results into a [Summary]
+func Summarise(results []DayResult) (summary Summary) {
+	for _, result := range results {
+		summary.Days++
+		if result.Failed() {
+			summary.FailedDays++
+		}
+
+		for _, part := range result.Parts {
+			summary.Parts++
+
+			switch {
+			case part.Status == StatusOK:
+				summary.Passed++
+			case part.Status == StatusNotImplemented:
+				summary.NotImplemented++
+			case part.Status.Failed():
+				summary.Failed++
+			}
+		}
+	}
+
+	return summary
+}
+
+// ExitCode returns the exit code a process should use after running
+// the days in this summary
+func (s Summary) ExitCode() int {
+	if s.FailedDays > 0 {
+		return 1
+	}
+
+	return 0
+}
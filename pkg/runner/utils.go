@@ -13,6 +13,7 @@ var repoDir string
 func init() {
 	// Setup the base logging
 	log.Logger = zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Caller().Logger()
+	zerolog.DefaultContextLogger = &log.Logger
 
 	// Find the repo root
 	var err error
@@ -36,3 +37,8 @@ func init() {
 
 	repoDir = filepath.Clean(repoDir)
 }
+
+// RepoDir returns the root directory of the repo
+func RepoDir() string {
+	return repoDir
+}
@@ -0,0 +1,259 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultVerifyTimeout is how long each run of a part or its reference is given when verifying,
+// unless the day sets its own timeout using [Day.WithTimeout]
+const DefaultVerifyTimeout = 10 * time.Second
+
+// ErrPartPanicked is returned when a part panics while being verified
+var ErrPartPanicked = errors.New("part panicked")
+
+// VerifyOptions controls which inputs a day is verified against
+type VerifyOptions struct {
+	InputOptions       // Where to read the inputs from
+	MaxInputSize int   // Inputs no larger than this many bytes are verified as well as the examples, zero to only use the examples
+	Generated    int   // How many inputs to generate and verify against, for days with a [Generator]
+	GenerateSize int   // The size of the generated inputs
+	Seed         int64 // The seed of the first generated input, with each following input using the next seed
+}
+
+// VerifyMismatch is a single disagreement between a part and its reference implementation
+type VerifyMismatch struct {
+	Part         int    // The part number
+	Input        string // The name of the input which caused the disagreement
+	Data         string // The input which caused the disagreement
+	Answer       Answer // The answer returned by the part
+	Err          error  // The error returned by the part
+	Reference    Answer // The answer returned by the reference implementation
+	ReferenceErr error  // The error returned by the reference implementation
+}
+
+// VerifyResult is the result of verifying a day against its reference implementations
+type VerifyResult struct {
+	Year       int              // The year of the event
+	Day        int              // The day number
+	References bool             // Set if the day has any reference implementations to verify against
+	Checked    int              // The number of times a part was compared against its reference
+	Skipped    int              // The number of comparisons skipped as either implementation timed out or wasn't implemented
+	Mismatches []VerifyMismatch // Every disagreement found
+	Err        error            // Set if the inputs could not be read
+}
+
+// ID returns the ID of the day the result is for
+func (r VerifyResult) ID() DayID {
+	return DayID{Year: r.Year, Day: r.Day}
+}
+
+// verifyInput is an input used to verify a day, along with the parts it applies to
+type verifyInput struct {
+	name  string
+	data  []byte
+	parts [2]bool // Whether the input is valid for part 1 and part 2
+}
+
+// WithReferencePart1 registers a reference implementation of part 1, such as a brute-force solution
+// which makes no assumptions about the input, for [Day.Verify] to check part 1 against
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithReferencePart1(part Part[Input, Part1Answer]) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part1Reference = eraseAnswerType(part)
+	return d
+}
+
+// WithReferencePart2 registers a reference implementation of part 2, such as a brute-force solution
+// which makes no assumptions about the input, for [Day.Verify] to check part 2 against
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) WithReferencePart2(part Part[Input, Part2Answer]) *Day[Input, Cache, Part1Answer, Part2Answer] {
+	d.part2Reference = eraseAnswerType(part)
+	return d
+}
+
+// Verify runs each part which has a reference implementation against the days examples,
+// any inputs no larger than [VerifyOptions.MaxInputSize] and any generated inputs, comparing the answers
+// of the part and its reference. Any disagreement is logged along with the input which
+// caused it and recorded in the result.
+//
+// Examples which only give an expected answer for one part are only used to verify that part.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) Verify(ctx context.Context, opts VerifyOptions) (result VerifyResult) {
+	result.Year = d.year
+	result.Day = d.day
+	logger := d.logger(log.Ctx(ctx))
+
+	if d.part1Reference == nil && d.part2Reference == nil {
+		logger.Debug().Msg("no reference implementations to verify against")
+		return result
+	}
+	result.References = true
+
+	inputs, err := d.verifyInputs(opts)
+	if err != nil {
+		result.Err = err
+		logger.Err(err).Msg("failed to read inputs to verify")
+		return result
+	}
+
+	for _, input := range inputs {
+		logger := logger.With().Str("_input", input.name).Logger()
+
+		if _, err := d.inputPreprocessor(input.data); err != nil {
+			logger.Warn().Err(err).Msg("skipping input which failed to parse")
+			continue
+		}
+
+		for i, pair := range [][2]anyPart[Input]{{d.part1, d.part1Reference}, {d.part2, d.part2Reference}} {
+			partNum := i + 1
+			part, reference := pair[0], pair[1]
+			if part == nil || reference == nil || !input.parts[i] {
+				continue
+			}
+
+			run := func(fn anyPart[Input]) (Answer, error) {
+				return d.runForVerify(ctx, partNum, fn, input.data)
+			}
+			mismatch := VerifyMismatch{Part: partNum, Input: input.name, Data: string(input.data)}
+			mismatch.Answer, mismatch.Err = run(part)
+			mismatch.Reference, mismatch.ReferenceErr = run(reference)
+
+			if verifySkipped(mismatch.Err) || verifySkipped(mismatch.ReferenceErr) {
+				logger.Warn().Int("_part", partNum).AnErr("part_err", mismatch.Err).AnErr("reference_err", mismatch.ReferenceErr).Msg("skipping verification")
+				result.Skipped++
+				continue
+			}
+
+			result.Checked++
+			if agree(mismatch) {
+				continue
+			}
+
+			result.Mismatches = append(result.Mismatches, mismatch)
+			logMismatch(logger, mismatch)
+		}
+	}
+
+	if len(result.Mismatches) == 0 {
+		logger.Info().Int("checked", result.Checked).Int("skipped", result.Skipped).Msg("parts agree with their references")
+	}
+
+	return result
+}
+
+// verifyInputs returns the examples for the day, any of the days inputs which are small enough
+// and the generated inputs
+//
+// Generated inputs are named after the seed used to generate them, so they can be recreated with the gen subcommand.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) verifyInputs(opts VerifyOptions) ([]verifyInput, error) {
+	examples, err := readExamples(d.id())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]verifyInput, 0, len(examples))
+	for _, ex := range examples {
+		parts := [2]bool{ex.part1 != nil, ex.part2 != nil}
+		if !parts[0] && !parts[1] {
+			parts = [2]bool{true, true}
+		}
+
+		inputs = append(inputs, verifyInput{name: "example/" + ex.name, data: []byte(ex.input), parts: parts})
+	}
+
+	if opts.MaxInputSize > 0 {
+		named, err := opts.readInputs(d.id())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, input := range named {
+			if len(input.data) <= opts.MaxInputSize {
+				inputs = append(inputs, verifyInput{name: input.name, data: input.data, parts: [2]bool{true, true}})
+			}
+		}
+	}
+
+	if d.generator != nil {
+		for i := 0; i < opts.Generated; i++ {
+			seed := opts.Seed + int64(i)
+			data, err := d.Generate(rand.New(rand.NewSource(seed)), opts.GenerateSize)
+			if err != nil {
+				return nil, err
+			}
+
+			name := fmt.Sprintf("generated/size=%d/seed=%d", opts.GenerateSize, seed)
+			inputs = append(inputs, verifyInput{name: name, data: data, parts: [2]bool{true, true}})
+		}
+	}
+
+	return inputs, nil
+}
+
+// runForVerify parses the input and runs the part against it, converting any panic into an error
+//
+// The input is parsed for each run, so a part which modifies its input can't affect the reference.
+func (d *Day[Input, Cache, Part1Answer, Part2Answer]) runForVerify(ctx context.Context, partNum int, fn anyPart[Input], data []byte) (Answer, error) {
+	cacheData, err := d.inputPreprocessor(data)
+	if err != nil {
+		return Answer{}, errors.Wrap(err, "failed to preprocess input")
+	}
+
+	limits := d.limitsFor(partNum, Limits{Timeout: DefaultVerifyTimeout})
+
+	answer, _, err := runLimited(ctx, limits, func(ctx context.Context) (answer Answer, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Wrapf(ErrPartPanicked, "%v", r)
+			}
+		}()
+
+		partCtx := &Context{
+			Context: ctx,
+			log:     zerolog.Nop(),
+			year:    d.year,
+			day:     d.day,
+			part:    partNum,
+		}
+
+		return fn(partCtx, zerolog.Nop(), d.cacheToInput(cacheData))
+	})
+
+	return answer, err
+}
+
+// verifySkipped returns true if the error means the comparison can't be made
+func verifySkipped(err error) bool {
+	return errors.IsAny(err, ErrNotImplemented, ErrTimedOut, ErrHeapBudgetExceeded)
+}
+
+// agree returns true if the part and its reference gave the same answer, or both returned an error
+func agree(m VerifyMismatch) bool {
+	if m.Err != nil || m.ReferenceErr != nil {
+		return m.Err != nil && m.ReferenceErr != nil
+	}
+
+	return m.Answer.Equal(m.Reference)
+}
+
+// logMismatch logs the disagreement along with the input which caused it
+func logMismatch(logger zerolog.Logger, m VerifyMismatch) {
+	event := logger.Error().Int("_part", m.Part)
+
+	if m.Err != nil {
+		event = event.AnErr("part_err", m.Err)
+	} else {
+		event = event.Stringer("answer", m.Answer)
+	}
+
+	if m.ReferenceErr != nil {
+		event = event.AnErr("reference_err", m.ReferenceErr)
+	} else {
+		event = event.Stringer("reference", m.Reference)
+	}
+
+	event.Str("input_data", m.Data).Msg("part disagrees with its reference")
+}
@@ -0,0 +1,58 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDay_Verify(t *testing.T) {
+	d := newTestDay(
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n * n, nil
+		},
+		func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+			return n, nil
+		},
+	)
+	d.WithReferencePart1(func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+		return n + n, nil
+	})
+	d.WithReferencePart2(func(_ *Context, _ zerolog.Logger, n int) (int, error) {
+		panic("reference panicked")
+	})
+
+	verify := func(input string) VerifyResult {
+		path := filepath.Join(t.TempDir(), "input.txt")
+		require.NoError(t, os.WriteFile(path, []byte(input), 0644))
+
+		return d.Verify(context.Background(), VerifyOptions{InputOptions: InputOptions{File: path}, MaxInputSize: 100})
+	}
+
+	result := verify("2")
+	assert.True(t, result.References)
+	assert.Equal(t, 2, result.Checked)
+	require.Len(t, result.Mismatches, 1, "only the panicking reference should disagree")
+	assert.Equal(t, 2, result.Mismatches[0].Part)
+	assert.ErrorIs(t, result.Mismatches[0].ReferenceErr, ErrPartPanicked)
+
+	result = verify("3")
+	require.Len(t, result.Mismatches, 2)
+	assert.Equal(t, "3", result.Mismatches[0].Data)
+	assert.Equal(t, AnswerOf(9), result.Mismatches[0].Answer)
+	assert.Equal(t, AnswerOf(6), result.Mismatches[0].Reference)
+
+	result = verify(strings.Repeat("1", 101))
+	assert.Zero(t, result.Checked, "inputs over the max size should be skipped")
+
+	d.part1Reference, d.part2Reference = nil, nil
+	result = verify("2")
+	assert.False(t, result.References)
+	assert.Zero(t, result.Checked)
+}
@@ -112,3 +112,114 @@ func (f filterStream[V]) Next() (V, error) {
 		}
 	}
 }
+
+// Scan returns a stream of the running result of applying the function to each element,
+// starting from the initial value, such as the running total of a stream.
+//
+// If the function returns an error the stream will stop and the error will be
+// returned.
+func Scan[V, A any](input Stream[V], initial A, fn func(acc A, value V) (A, error)) Stream[A] {
+	return &scanStream[V, A]{
+		input: input,
+		acc:   initial,
+		fn:    fn,
+	}
+}
+
+type scanStream[V, A any] struct {
+	input Stream[V]
+	acc   A
+	fn    func(A, V) (A, error)
+}
+
+func (s *scanStream[V, A]) Next() (next A, err error) {
+	input, err := s.input.Next()
+	if err != nil {
+		return next, err
+	}
+
+	acc, err := s.fn(s.acc, input)
+	if err != nil {
+		return next, err
+	}
+
+	s.acc = acc
+	return acc, nil
+}
+
+// TakeWhile returns a stream of the elements up until the first one which doesn't match
+// the given predicate, after which the stream is finished and the input is not read any further.
+//
+// The first element which doesn't match is read from the input and dropped. If the predicate
+// returns an error the stream will stop and the error will be returned.
+func TakeWhile[V any](input Stream[V], predicate func(V) (bool, error)) Stream[V] {
+	return &takeWhileStream[V]{
+		input: input,
+		fn:    predicate,
+	}
+}
+
+type takeWhileStream[V any] struct {
+	input Stream[V]
+	fn    func(V) (bool, error)
+	done  bool
+}
+
+func (t *takeWhileStream[V]) Next() (next V, err error) {
+	if t.done {
+		return next, io.EOF
+	}
+
+	input, err := t.input.Next()
+	if err != nil {
+		return next, err
+	}
+
+	keep, err := t.fn(input)
+	if err != nil {
+		return next, err
+	}
+
+	if !keep {
+		t.done = true
+		return next, io.EOF
+	}
+
+	return input, nil
+}
+
+// DropWhile returns a stream which skips elements while they match the given predicate,
+// then returns every element from the first one which doesn't match.
+//
+// If the predicate returns an error the stream will stop and the error will be returned.
+func DropWhile[V any](input Stream[V], predicate func(V) (bool, error)) Stream[V] {
+	return &dropWhileStream[V]{
+		input: input,
+		fn:    predicate,
+	}
+}
+
+type dropWhileStream[V any] struct {
+	input Stream[V]
+	fn    func(V) (bool, error)
+	kept  bool // Set once an element has been kept, after which nothing more is dropped
+}
+
+func (d *dropWhileStream[V]) Next() (next V, err error) {
+	for {
+		input, err := d.input.Next()
+		if err != nil || d.kept {
+			return input, err
+		}
+
+		drop, err := d.fn(input)
+		if err != nil {
+			return next, err
+		}
+
+		if !drop {
+			d.kept = true
+			return input, nil
+		}
+	}
+}
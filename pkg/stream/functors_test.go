@@ -0,0 +1,43 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	sum := func(acc, value int) (int, error) { return acc + value, nil }
+
+	assertCollect(t, Scan(From([]int{1, 2, 3}), 10, sum), nil, 11, 13, 16)
+	assertCollect(t, Scan(failAfter(1, 2), 0, sum), errTest, 1, 3)
+	assertCollect(t, Scan(From([]int{1, 2}), 0, func(acc, value int) (int, error) {
+		if value == 2 {
+			return 0, errTest
+		}
+		return acc + value, nil
+	}), errTest, 1)
+}
+
+func TestTakeWhile(t *testing.T) {
+	small := func(v int) (bool, error) { return v < 3, nil }
+
+	input := From([]int{1, 2, 3, 4, 1})
+	assertCollect(t, TakeWhile(input, small), nil, 1, 2)
+	next, err := input.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 4, next, "only the first element which doesn't match should be read")
+
+	assertCollect(t, TakeWhile(failAfter(1), small), errTest, 1)
+	assertCollect(t, TakeWhile(From([]int{1}), func(int) (bool, error) { return false, errTest }), errTest)
+}
+
+func TestDropWhile(t *testing.T) {
+	small := func(v int) (bool, error) { return v < 3, nil }
+
+	assertCollect(t, DropWhile(From([]int{1, 2, 3, 1, 4}), small), nil, 3, 1, 4)
+	assertCollect(t, DropWhile(From([]int{1, 2}), small), nil)
+	assertCollect(t, DropWhile(failAfter(1, 3), small), errTest, 3)
+	assertCollect(t, DropWhile(From([]int{1}), func(int) (bool, error) { return false, errTest }), errTest)
+}
@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"io"
+	"iter"
+
+	"github.com/cockroachdb/errors"
+)
+
+// All returns an iterator over the values in the stream, for use with range:
+//
+//	for line, err := range stream.All(lines) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The stream finishing with [io.EOF] ends the iteration, while any other error is
+// yielded with a zero value and then ends the iteration. Breaking out of the loop
+// leaves the rest of the stream unread.
+func All[V any](input Stream[V]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for {
+			v, err := input.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			} else if err != nil {
+				var zero V
+				yield(zero, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq returns a stream of the values from the iterator, such as those from [slices.Values] or [maps.Keys]
+//
+// The iterator is pulled one value at a time as the stream is read. If the stream is
+// not read until [io.EOF], the iterator is left suspended until it is garbage collected.
+func FromSeq[V any](seq iter.Seq[V]) Stream[V] {
+	return FromSeq2(func(yield func(V, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	})
+}
+
+// FromSeq2 returns a stream of the values from the iterator, which stops with the first error it yields
+//
+// Once the iterator has finished or yielded an error, every following call to Next returns
+// [io.EOF] or that error. See [FromSeq] for how the iterator is pulled.
+func FromSeq2[V any](seq iter.Seq2[V, error]) Stream[V] {
+	next, stop := iter.Pull2(seq)
+	return &seqSource[V]{next: next, stop: stop}
+}
+
+type seqSource[V any] struct {
+	next func() (V, error, bool)
+	stop func()
+	err  error // Set once the iterator has finished
+}
+
+func (s *seqSource[V]) Next() (V, error) {
+	var zero V
+	if s.err != nil {
+		return zero, s.err
+	}
+
+	v, err, ok := s.next()
+	if !ok {
+		err = io.EOF
+	}
+	if err != nil {
+		s.err = err
+		s.stop()
+		return zero, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	var got []int
+	for v, err := range All(From([]int{1, 2, 3})) {
+		require.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	// Breaking out of the loop leaves the rest of the stream unread
+	input := From([]int{1, 2, 3})
+	for v := range All(input) {
+		if v == 2 {
+			break
+		}
+	}
+	next, err := input.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, next, "stream should continue after the value the loop stopped on")
+
+	// Errors other than io.EOF are yielded once and end the iteration
+	failed := errors.New("failed")
+	var errs []error
+	for _, err := range All[int](&errStream[int]{err: failed}) {
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []error{failed}, errs)
+}
+
+func TestFromSeq(t *testing.T) {
+	values, err := Collect(FromSeq(slices.Values([]string{"a", "b", "c"})))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	keys, err := Collect(FromSeq(maps.Keys(map[int]bool{1: true, 2: true})))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2}, keys)
+}
+
+func TestFromSeq2(t *testing.T) {
+	failed := errors.New("failed")
+	s := FromSeq2(func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, failed) && yield(2, nil)
+	})
+
+	v, err := s.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	for range 2 {
+		_, err = s.Next()
+		assert.ErrorIs(t, err, failed, "the error should be returned on every call")
+	}
+
+	s = FromSeq2(func(yield func(int, error) bool) {})
+	for range 2 {
+		_, err = s.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	}
+}
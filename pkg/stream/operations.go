@@ -1,6 +1,8 @@
 package stream
 
 import (
+	"io"
+
 	"github.com/cockroachdb/errors"
 )
 
@@ -63,3 +65,234 @@ func (b *partitionStream[V]) Next() (next V, err error) {
 
 	return next, nil
 }
+
+// Pair is two values, such as the elements of two streams zipped together
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a stream of pairs of the elements from both streams, in order,
+// which finishes as soon as either stream finishes.
+func Zip[A, B any](a Stream[A], b Stream[B]) Stream[Pair[A, B]] {
+	return zipStream[A, B]{a: a, b: b}
+}
+
+type zipStream[A, B any] struct {
+	a Stream[A]
+	b Stream[B]
+}
+
+func (z zipStream[A, B]) Next() (next Pair[A, B], err error) {
+	if next.First, err = z.a.Next(); err != nil {
+		return Pair[A, B]{}, err
+	}
+
+	if next.Second, err = z.b.Next(); err != nil {
+		return Pair[A, B]{}, err
+	}
+
+	return next, nil
+}
+
+// Enumerate returns a stream of the elements paired with their index, starting at zero
+func Enumerate[V any](input Stream[V]) Stream[Pair[int, V]] {
+	return &enumerateStream[V]{input: input}
+}
+
+type enumerateStream[V any] struct {
+	input Stream[V]
+	idx   int
+}
+
+func (e *enumerateStream[V]) Next() (next Pair[int, V], err error) {
+	value, err := e.input.Next()
+	if err != nil {
+		return next, err
+	}
+
+	next = Pair[int, V]{First: e.idx, Second: value}
+	e.idx++
+	return next, nil
+}
+
+// Window returns a stream of every run of size consecutive elements, so a stream of
+// 1, 2, 3, 4 with a size of 2 gives [1 2], [2 3], [3 4].
+//
+// Each window is a new slice, so it can be kept. If the input has fewer elements than
+// the size, no windows are returned.
+func Window[V any](input Stream[V], size int) Stream[[]V] {
+	if size < 1 {
+		return errStream[[]V]{err: errors.Newf("window size must be at least 1, got %d", size)}
+	}
+
+	return &windowStream[V]{input: input, size: size}
+}
+
+type windowStream[V any] struct {
+	input  Stream[V]
+	size   int
+	window []V
+}
+
+func (w *windowStream[V]) Next() (next []V, err error) {
+	if len(w.window) == w.size {
+		w.window = w.window[1:]
+	}
+
+	for len(w.window) < w.size {
+		value, err := w.input.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		w.window = append(w.window, value)
+	}
+
+	next = make([]V, w.size)
+	copy(next, w.window)
+	return next, nil
+}
+
+// Chunk returns a stream of the elements grouped into slices of the given size,
+// with the last chunk holding any remaining elements.
+func Chunk[V any](input Stream[V], size int) Stream[[]V] {
+	if size < 1 {
+		return errStream[[]V]{err: errors.Newf("chunk size must be at least 1, got %d", size)}
+	}
+
+	return chunkStream[V]{input: input, size: size}
+}
+
+type chunkStream[V any] struct {
+	input Stream[V]
+	size  int
+}
+
+func (c chunkStream[V]) Next() ([]V, error) {
+	chunk := make([]V, 0, c.size)
+	for len(chunk) < c.size {
+		value, err := c.input.Next()
+		if errors.Is(err, io.EOF) && len(chunk) > 0 {
+			return chunk, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		chunk = append(chunk, value)
+	}
+
+	return chunk, nil
+}
+
+// SplitWhen returns a stream of groups of elements, split by the elements which match the
+// separator predicate, such as splitting lines into the blocks separated by blank lines:
+//
+//	blocks := stream.SplitWhen(lines, func(line string) (bool, error) {
+//		return line == "", nil
+//	})
+//
+// The separators are not included in the groups, and empty groups are skipped. If the predicate
+// returns an error the stream will stop and the error will be returned.
+func SplitWhen[V any](input Stream[V], isSeparator func(V) (bool, error)) Stream[[]V] {
+	return splitWhenStream[V]{input: input, fn: isSeparator}
+}
+
+type splitWhenStream[V any] struct {
+	input Stream[V]
+	fn    func(V) (bool, error)
+}
+
+func (s splitWhenStream[V]) Next() ([]V, error) {
+	var group []V
+	for {
+		value, err := s.input.Next()
+		if errors.Is(err, io.EOF) && len(group) > 0 {
+			return group, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		separator, err := s.fn(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if !separator {
+			group = append(group, value)
+		} else if len(group) > 0 {
+			return group, nil
+		}
+	}
+}
+
+// Take returns a stream of the first n elements, after which the input is not read any further
+func Take[V any](input Stream[V], n int) Stream[V] {
+	return &takeStream[V]{input: input, remaining: n}
+}
+
+type takeStream[V any] struct {
+	input     Stream[V]
+	remaining int
+}
+
+func (t *takeStream[V]) Next() (next V, err error) {
+	if t.remaining <= 0 {
+		return next, io.EOF
+	}
+
+	next, err = t.input.Next()
+	if err != nil {
+		return next, err
+	}
+
+	t.remaining--
+	return next, nil
+}
+
+// Skip returns a stream which skips the first n elements
+func Skip[V any](input Stream[V], n int) Stream[V] {
+	return &skipStream[V]{input: input, remaining: n}
+}
+
+type skipStream[V any] struct {
+	input     Stream[V]
+	remaining int
+}
+
+func (s *skipStream[V]) Next() (next V, err error) {
+	for ; s.remaining > 0; s.remaining-- {
+		if _, err := s.input.Next(); err != nil {
+			return next, err
+		}
+	}
+
+	return s.input.Next()
+}
+
+// Distinct returns a stream of the elements which haven't been seen before in the stream,
+// keeping the first occurrence of each.
+//
+// Every distinct element is remembered, so memory grows with the number of distinct elements.
+func Distinct[V comparable](input Stream[V]) Stream[V] {
+	return distinctStream[V]{input: input, seen: make(map[V]struct{})}
+}
+
+type distinctStream[V comparable] struct {
+	input Stream[V]
+	seen  map[V]struct{}
+}
+
+func (d distinctStream[V]) Next() (next V, err error) {
+	for {
+		next, err = d.input.Next()
+		if err != nil {
+			return next, err
+		}
+
+		if _, found := d.seen[next]; !found {
+			d.seen[next] = struct{}{}
+			return next, nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+// failAfter returns a stream of the values followed by errTest
+func failAfter[V any](values ...V) Stream[V] {
+	return FromSeq2(func(yield func(V, error) bool) {
+		for _, v := range values {
+			if !yield(v, nil) {
+				return
+			}
+		}
+
+		var zero V
+		yield(zero, errTest)
+	})
+}
+
+// assertCollect asserts the stream returns the expected values and then finishes with the expected error
+func assertCollect[V any](t *testing.T, s Stream[V], wantErr error, want ...V) {
+	t.Helper()
+
+	got, err := Collect(s)
+	if wantErr == nil {
+		assert.NoError(t, err)
+	} else {
+		assert.ErrorIs(t, err, wantErr)
+	}
+
+	if len(want) == 0 {
+		assert.Empty(t, got)
+	} else {
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestZip(t *testing.T) {
+	assertCollect(t, Zip(From([]int{1, 2, 3}), From([]string{"a", "b"})), nil,
+		Pair[int, string]{1, "a"}, Pair[int, string]{2, "b"},
+	)
+
+	// The second stream is not read once the first has finished
+	second := From([]string{"a", "b"})
+	assertCollect(t, Zip(From([]int{1}), second), nil, Pair[int, string]{1, "a"})
+	next, err := second.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "b", next)
+
+	assertCollect(t, Zip(failAfter(1, 2), From([]string{"a", "b", "c"})), errTest, Pair[int, string]{1, "a"}, Pair[int, string]{2, "b"})
+	assertCollect(t, Zip(From([]int{1, 2}), failAfter("a")), errTest, Pair[int, string]{1, "a"})
+}
+
+func TestEnumerate(t *testing.T) {
+	assertCollect(t, Enumerate(From([]string{"a", "b"})), nil, Pair[int, string]{0, "a"}, Pair[int, string]{1, "b"})
+	assertCollect(t, Enumerate(failAfter("a")), errTest, Pair[int, string]{0, "a"})
+}
+
+func TestWindow(t *testing.T) {
+	assertCollect(t, Window(From([]int{1, 2, 3, 4}), 2), nil, []int{1, 2}, []int{2, 3}, []int{3, 4})
+	assertCollect(t, Window(From([]int{1, 2, 3}), 3), nil, []int{1, 2, 3})
+	assertCollect(t, Window(From([]int{1, 2}), 3), nil)
+	assertCollect(t, Window(failAfter(1, 2, 3), 2), errTest, []int{1, 2}, []int{2, 3})
+
+	_, err := Window(From([]int{1}), 0).Next()
+	assert.Error(t, err)
+}
+
+func TestChunk(t *testing.T) {
+	assertCollect(t, Chunk(From([]int{1, 2, 3, 4, 5}), 2), nil, []int{1, 2}, []int{3, 4}, []int{5})
+	assertCollect(t, Chunk(From([]int{}), 2), nil)
+	assertCollect(t, Chunk(failAfter(1, 2, 3), 2), errTest, []int{1, 2})
+
+	_, err := Chunk(From([]int{1}), 0).Next()
+	assert.Error(t, err)
+}
+
+func TestSplitWhen(t *testing.T) {
+	isBlank := func(line string) (bool, error) { return line == "", nil }
+
+	lines := LinesFrom([]byte("#.#\n..#\n\n\n##.\n\n#..\n"))
+	assertCollect(t, SplitWhen(lines, isBlank), nil, []string{"#.#", "..#"}, []string{"##."}, []string{"#.."})
+	assertCollect(t, SplitWhen(failAfter("a", "", "b"), isBlank), errTest, []string{"a"})
+
+	assertCollect(t, SplitWhen(From([]string{"a"}), func(string) (bool, error) {
+		return false, errTest
+	}), errTest)
+}
+
+func TestTakeAndSkip(t *testing.T) {
+	input := From([]int{1, 2, 3, 4})
+	assertCollect(t, Take(input, 2), nil, 1, 2)
+	next, err := input.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, next, "take should not read past the elements it returns")
+
+	assertCollect(t, Take(From([]int{1}), 2), nil, 1)
+	assertCollect(t, Take(failAfter(1), 2), errTest, 1)
+
+	assertCollect(t, Skip(From([]int{1, 2, 3}), 2), nil, 3)
+	assertCollect(t, Skip(From([]int{1, 2, 3}), 5), nil)
+	assertCollect(t, Skip(failAfter(1), 2), errTest)
+}
+
+func TestDistinct(t *testing.T) {
+	assertCollect(t, Distinct(From([]int{1, 2, 1, 3, 2, 4})), nil, 1, 2, 3, 4)
+	assertCollect(t, Distinct(failAfter(1, 1, 2)), errTest, 1, 2)
+
+	_, err := Distinct(From([]int{})).Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
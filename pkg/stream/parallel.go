@@ -0,0 +1,178 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ParallelMap returns a new stream with the given function applied to each element by a pool
+// of workers, keeping the elements in the same order as the input stream.
+//
+// If workers is less than one, [runtime.GOMAXPROCS] workers are used. At most twice as many elements
+// as there are workers are read from the input ahead of the element being returned, so a slow
+// element holds up the workers rather than letting the results pile up in memory.
+//
+// If the function or the input returns an error, the workers are stopped and the stream returns
+// that error. If the context is cancelled the stream returns the cause of the cancellation, so
+// cancelling the context also releases the workers if the stream is not read to the end.
+//
+// The input stream is only read from a single goroutine, however the function is called concurrently.
+func ParallelMap[A, B any](ctx context.Context, input Stream[A], workers int, fn func(A) (B, error)) Stream[B] {
+	return newParallelMap(ctx, input, workers, fn, true)
+}
+
+// ParallelMapUnordered is like [ParallelMap], however the elements are returned in the order
+// the workers finish them, so one slow element does not hold up the rest of the stream.
+func ParallelMapUnordered[A, B any](ctx context.Context, input Stream[A], workers int, fn func(A) (B, error)) Stream[B] {
+	return newParallelMap(ctx, input, workers, fn, false)
+}
+
+// parallelItem is an element of the stream along with its position in the input
+type parallelItem[V any] struct {
+	idx   int
+	value V
+}
+
+type parallelMapStream[B any] struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	results chan parallelItem[B]
+	tokens  chan struct{} // Holds a token for each element read from the input but not yet returned
+	ordered bool
+	pending map[int]B // Finished elements waiting on an earlier element, when ordered
+	nextIdx int       // The position of the next element to return, when ordered
+	err     error     // Set once the stream has finished
+}
+
+func newParallelMap[A, B any](ctx context.Context, input Stream[A], workers int, fn func(A) (B, error), ordered bool) Stream[B] {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	s := &parallelMapStream[B]{
+		ctx:     ctx,
+		cancel:  cancel,
+		results: make(chan parallelItem[B]),
+		tokens:  make(chan struct{}, 2*workers),
+		ordered: ordered,
+		pending: make(map[int]B),
+	}
+
+	// Read the input on a single goroutine, as streams are not safe for concurrent use
+	jobs := make(chan parallelItem[A])
+	go func() {
+		defer close(jobs)
+
+		for idx := 0; ; idx++ {
+			select {
+			case s.tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			next, err := input.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			} else if err != nil {
+				cancel(err)
+				return
+			}
+
+			select {
+			case jobs <- parallelItem[A]{idx: idx, value: next}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				var job parallelItem[A]
+				select {
+				case <-ctx.Done():
+					return
+				case next, ok := <-jobs:
+					if !ok {
+						return
+					}
+					job = next
+				}
+
+				result, err := fn(job.value)
+				if err != nil {
+					cancel(err)
+					return
+				}
+
+				select {
+				case s.results <- parallelItem[B]{idx: job.idx, value: result}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(s.results)
+	}()
+
+	return s
+}
+
+func (s *parallelMapStream[B]) Next() (next B, err error) {
+	if s.err != nil {
+		return next, s.err
+	}
+
+	for {
+		if s.ordered {
+			if value, found := s.pending[s.nextIdx]; found {
+				delete(s.pending, s.nextIdx)
+				s.nextIdx++
+				<-s.tokens
+				return value, nil
+			}
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return next, s.finish(context.Cause(s.ctx))
+
+		case result, ok := <-s.results:
+			if !ok {
+				// The workers also stop if the context is cancelled, so check it wasn't the cause
+				if s.ctx.Err() != nil {
+					return next, s.finish(context.Cause(s.ctx))
+				}
+				return next, s.finish(io.EOF)
+			}
+
+			if !s.ordered {
+				<-s.tokens
+				return result.value, nil
+			}
+			s.pending[result.idx] = result.value
+		}
+	}
+}
+
+// finish records the error the stream finished with and stops any workers still running
+func (s *parallelMapStream[B]) finish(err error) error {
+	s.err = err
+	s.pending = nil
+	s.cancel(err)
+	return err
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStream counts how many values have been read from the stream
+type countingStream struct {
+	Stream[int]
+	read atomic.Int64
+}
+
+func (c *countingStream) Next() (int, error) {
+	c.read.Add(1)
+	return c.Stream.Next()
+}
+
+func numbers(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+func TestParallelMap(t *testing.T) {
+	double := func(v int) (int, error) {
+		// Make the early elements the slowest to finish
+		time.Sleep(time.Duration(100-v) * time.Microsecond)
+		return v * 2, nil
+	}
+
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i * 2
+	}
+
+	got, err := Collect(ParallelMap(context.Background(), From(numbers(100)), 4, double))
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "ordered results should keep the input order")
+
+	got, err = Collect(ParallelMapUnordered(context.Background(), From(numbers(100)), 4, double))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, want, got)
+
+	got, err = Collect(ParallelMap(context.Background(), From([]int{}), 0, double))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestParallelMap_Errors(t *testing.T) {
+	failed := errors.New("failed")
+
+	for name, parallelMap := range map[string]func(context.Context, Stream[int], int, func(int) (int, error)) Stream[int]{
+		"ordered":   ParallelMap[int, int],
+		"unordered": ParallelMapUnordered[int, int],
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := &countingStream{Stream: From(numbers(1000))}
+			s := parallelMap(context.Background(), input, 4, func(v int) (int, error) {
+				if v == 10 {
+					return 0, failed
+				}
+				return v, nil
+			})
+
+			_, err := Collect(s)
+			assert.ErrorIs(t, err, failed)
+			_, err = s.Next()
+			assert.ErrorIs(t, err, failed, "the error should be returned on every call")
+			assert.Less(t, input.read.Load(), int64(100), "the input should stop being read after an error")
+
+			_, err = Collect(parallelMap(context.Background(), &errStream[int]{err: failed}, 4, func(v int) (int, error) {
+				return v, nil
+			}))
+			assert.ErrorIs(t, err, failed, "errors from the input should be returned")
+		})
+	}
+}
+
+func TestParallelMap_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	input := &countingStream{Stream: From(numbers(1000))}
+	s := ParallelMap(ctx, input, 2, func(v int) (int, error) {
+		if v == 0 {
+			<-release
+		}
+		return v, nil
+	})
+
+	// The first element blocks, so the stream should only read ahead as far as its buffer allows
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, input.read.Load(), int64(4), "the input should not be read ahead past the buffer")
+
+	cancel()
+	_, err := s.Next()
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = s.Next()
+	assert.ErrorIs(t, err, context.Canceled, "a cancelled stream should not look like it finished")
+}
@@ -1,11 +1,18 @@
 package stream
 
 import (
-	"errors"
+	"cmp"
 	"fmt"
-	"io"
+
+	"github.com/cockroachdb/errors"
 )
 
+// ErrEmptyStream is returned by sinks which have no answer for a stream with no values, such as [Min] or [First]
+var ErrEmptyStream = errors.New("stream is empty")
+
+// ErrDuplicateKey is returned by [ToMap] if the same key appears more than once
+var ErrDuplicateKey = errors.New("duplicate key")
+
 // numeric is a constraint that limits what type of streams can be used with certain sinks
 // such as [Sum] or [SumToString].
 type numeric interface {
@@ -14,49 +21,40 @@ type numeric interface {
 
 // ForEach applies the given function to each value in the stream.
 func ForEach[V any](input Stream[V], fn func(V) error) error {
-	for {
-		v, err := input.Next()
-
-		if errors.Is(err, io.EOF) {
-			return nil
-		} else if err != nil {
+	for v, err := range All(input) {
+		if err != nil {
 			return err
 		}
 
-		err = fn(v)
-		if err != nil {
+		if err := fn(v); err != nil {
 			return err
 		}
 	}
+
+	return nil
 }
 
 // Collect returns all values from the stream as a slice.
 func Collect[V any](input Stream[V]) ([]V, error) {
 	var values []V
 
-	for {
-		v, err := input.Next()
-
-		if errors.Is(err, io.EOF) {
-			return values, nil
-		} else if err != nil {
+	for v, err := range All(input) {
+		if err != nil {
 			return values, err
 		}
 
 		values = append(values, v)
 	}
+
+	return values, nil
 }
 
 // Reduce returns a single value from the stream by applying the reducer function to each value in the stream.
 func Reduce[V, A any](input Stream[V], reducer func(acc A, value V) (A, error)) (A, error) {
 	var value A
 
-	for {
-		v, err := input.Next()
-
-		if errors.Is(err, io.EOF) {
-			return value, nil
-		} else if err != nil {
+	for v, err := range All(input) {
+		if err != nil {
 			return value, err
 		}
 
@@ -65,6 +63,8 @@ func Reduce[V, A any](input Stream[V], reducer func(acc A, value V) (A, error))
 			return value, err
 		}
 	}
+
+	return value, nil
 }
 
 // Sum returns the sum of all values in the stream.
@@ -83,3 +83,180 @@ func SumToString[V numeric](input Stream[V]) (string, error) {
 
 	return fmt.Sprintf("%v", sum), nil
 }
+
+// Count returns the number of values in the stream.
+func Count[V any](input Stream[V]) (int, error) {
+	return Reduce[V, int](input, func(acc int, _ V) (int, error) {
+		return acc + 1, nil
+	})
+}
+
+// Product returns the product of all values in the stream, which is 1 if there are none.
+func Product[V numeric](input Stream[V]) (V, error) {
+	product := V(1)
+	for v, err := range All(input) {
+		if err != nil {
+			return product, err
+		}
+
+		product *= v
+	}
+
+	return product, nil
+}
+
+// Min returns the smallest value in the stream, or [ErrEmptyStream] if there are none.
+func Min[V cmp.Ordered](input Stream[V]) (V, error) {
+	return MinBy(input, identity[V])
+}
+
+// Max returns the largest value in the stream, or [ErrEmptyStream] if there are none.
+func Max[V cmp.Ordered](input Stream[V]) (V, error) {
+	return MaxBy(input, identity[V])
+}
+
+// MinBy returns the value in the stream with the smallest key, or [ErrEmptyStream] if there are none.
+//
+// If more than one value has the smallest key, the first of them is returned.
+func MinBy[V any, K cmp.Ordered](input Stream[V], key func(V) (K, error)) (V, error) {
+	return bestBy(input, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the value in the stream with the largest key, or [ErrEmptyStream] if there are none.
+//
+// If more than one value has the largest key, the first of them is returned.
+func MaxBy[V any, K cmp.Ordered](input Stream[V], key func(V) (K, error)) (V, error) {
+	return bestBy(input, key, func(a, b K) bool { return a > b })
+}
+
+// bestBy returns the first value in the stream whose key no other key is better than
+func bestBy[V any, K cmp.Ordered](input Stream[V], key func(V) (K, error), better func(a, b K) bool) (best V, err error) {
+	var bestKey K
+	found := false
+
+	for v, err := range All(input) {
+		if err != nil {
+			return best, err
+		}
+
+		k, err := key(v)
+		if err != nil {
+			return best, err
+		}
+
+		if !found || better(k, bestKey) {
+			best, bestKey, found = v, k, true
+		}
+	}
+
+	if !found {
+		return best, errors.WithStack(ErrEmptyStream)
+	}
+
+	return best, nil
+}
+
+// identity is a key function which uses the value as its own key
+func identity[V any](v V) (V, error) {
+	return v, nil
+}
+
+// GroupBy collects the values in the stream into a map of the values with each key, keeping the order
+// the values appeared in the stream.
+func GroupBy[V any, K comparable](input Stream[V], key func(V) (K, error)) (map[K][]V, error) {
+	groups := make(map[K][]V)
+
+	for v, err := range All(input) {
+		if err != nil {
+			return groups, err
+		}
+
+		k, err := key(v)
+		if err != nil {
+			return groups, err
+		}
+
+		groups[k] = append(groups[k], v)
+	}
+
+	return groups, nil
+}
+
+// ToMap collects the pairs in the stream into a map from the first value of each pair to the second,
+// such as the output of [Zip] or [Enumerate]. If a key appears more than once [ErrDuplicateKey] is returned.
+func ToMap[K comparable, V any](input Stream[Pair[K, V]]) (map[K]V, error) {
+	m := make(map[K]V)
+
+	for pair, err := range All(input) {
+		if err != nil {
+			return m, err
+		}
+
+		if _, found := m[pair.First]; found {
+			return m, errors.Wrapf(ErrDuplicateKey, "%v", pair.First)
+		}
+
+		m[pair.First] = pair.Second
+	}
+
+	return m, nil
+}
+
+// AnyMatch returns true if any value in the stream matches the predicate, stopping
+// reading the stream as soon as one does. An empty stream returns false.
+func AnyMatch[V any](input Stream[V], predicate func(V) (bool, error)) (bool, error) {
+	for v, err := range All(input) {
+		if err != nil {
+			return false, err
+		}
+
+		match, err := predicate(v)
+		if err != nil {
+			return false, err
+		} else if match {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// AllMatch returns true if every value in the stream matches the predicate, stopping
+// reading the stream as soon as one doesn't. An empty stream returns true.
+func AllMatch[V any](input Stream[V], predicate func(V) (bool, error)) (bool, error) {
+	match, err := AnyMatch(input, func(v V) (bool, error) {
+		match, err := predicate(v)
+		return !match, err
+	})
+
+	return !match && err == nil, err
+}
+
+// First returns the first value in the stream without reading any further, or [ErrEmptyStream] if there are none.
+func First[V any](input Stream[V]) (V, error) {
+	for v, err := range All(input) {
+		return v, err
+	}
+
+	var zero V
+	return zero, errors.WithStack(ErrEmptyStream)
+}
+
+// Last returns the last value in the stream, or [ErrEmptyStream] if there are none.
+func Last[V any](input Stream[V]) (last V, err error) {
+	found := false
+
+	for v, err := range All(input) {
+		if err != nil {
+			return last, err
+		}
+
+		last, found = v, true
+	}
+
+	if !found {
+		return last, errors.WithStack(ErrEmptyStream)
+	}
+
+	return last, nil
+}
@@ -0,0 +1,162 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	count, err := Count(From([]string{"a", "b", "c"}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = Count(From([]string{}))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = Count(failAfter("a"))
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestProduct(t *testing.T) {
+	product, err := Product(From([]int{2, 3, 4}))
+	require.NoError(t, err)
+	assert.Equal(t, 24, product)
+
+	product, err = Product(From([]int{2, 0, 4}))
+	require.NoError(t, err)
+	assert.Zero(t, product)
+
+	product, err = Product(From([]int{}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, product, "the product of no values should be 1")
+
+	_, err = Product(failAfter(1, 2))
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestMinMax(t *testing.T) {
+	min, err := Min(From([]int{3, 1, 2}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, min)
+
+	max, err := Max(From([]int{3, 1, 5, 2}))
+	require.NoError(t, err)
+	assert.Equal(t, 5, max)
+
+	_, err = Min(From([]int{}))
+	assert.ErrorIs(t, err, ErrEmptyStream)
+	_, err = Max(From([]int{}))
+	assert.ErrorIs(t, err, ErrEmptyStream)
+	_, err = Max(failAfter(1))
+	assert.ErrorIs(t, err, errTest)
+
+	words := []string{"bb", "a", "ccc", "dd", "e", "fff"}
+	length := func(s string) (int, error) { return len(s), nil }
+
+	shortest, err := MinBy(From(words), length)
+	require.NoError(t, err)
+	assert.Equal(t, "a", shortest, "the first of the values with the smallest key should be returned")
+
+	longest, err := MaxBy(From(words), length)
+	require.NoError(t, err)
+	assert.Equal(t, "ccc", longest, "the first of the values with the largest key should be returned")
+
+	_, err = MaxBy(From(words), func(string) (int, error) { return 0, errTest })
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestGroupBy(t *testing.T) {
+	groups, err := GroupBy(From([]string{"apple", "bean", "avocado", "beet", "cherry"}), func(s string) (byte, error) {
+		return s[0], nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"bean", "beet"},
+		'c': {"cherry"},
+	}, groups)
+
+	groups, err = GroupBy(From([]string{}), func(s string) (byte, error) { return s[0], nil })
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	_, err = GroupBy(failAfter("a"), func(s string) (byte, error) { return s[0], nil })
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestToMap(t *testing.T) {
+	m, err := ToMap(Zip(From([]string{"a", "b"}), From([]int{1, 2})))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+
+	indexes, err := ToMap(Enumerate(From([]string{"x", "y"})))
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{0: "x", 1: "y"}, indexes)
+
+	_, err = ToMap(Zip(From([]string{"a", "a"}), From([]int{1, 2})))
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+}
+
+func TestAnyAllMatch(t *testing.T) {
+	isEven := func(v int) (bool, error) { return v%2 == 0, nil }
+
+	input := From([]int{1, 2, 3, 5})
+	found, err := AnyMatch(input, isEven)
+	require.NoError(t, err)
+	assert.True(t, found)
+	next, err := input.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, next, "AnyMatch should stop reading at the first match")
+
+	found, err = AnyMatch(From([]int{1, 3}), isEven)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	found, err = AnyMatch(From([]int{}), isEven)
+	require.NoError(t, err)
+	assert.False(t, found, "no value in an empty stream matches")
+
+	input = From([]int{2, 3, 4})
+	all, err := AllMatch(input, isEven)
+	require.NoError(t, err)
+	assert.False(t, all)
+	next, err = input.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 4, next, "AllMatch should stop reading at the first value which doesn't match")
+
+	all, err = AllMatch(From([]int{2, 4}), isEven)
+	require.NoError(t, err)
+	assert.True(t, all)
+
+	all, err = AllMatch(From([]int{}), isEven)
+	require.NoError(t, err)
+	assert.True(t, all, "every value in an empty stream matches")
+
+	_, err = AllMatch(failAfter(2), isEven)
+	assert.ErrorIs(t, err, errTest)
+	_, err = AnyMatch(From([]int{1}), func(int) (bool, error) { return true, errTest })
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestFirstLast(t *testing.T) {
+	input := LinesFrom([]byte("one\ntwo\nthree"))
+	first, err := First(input)
+	require.NoError(t, err)
+	assert.Equal(t, "one", first)
+
+	last, err := Last(input)
+	require.NoError(t, err)
+	assert.Equal(t, "three", last, "First should only read the first value")
+
+	_, err = First(From([]string{}))
+	assert.ErrorIs(t, err, ErrEmptyStream)
+	_, err = Last(From([]string{}))
+	assert.ErrorIs(t, err, ErrEmptyStream)
+	_, err = Last(failAfter("a"))
+	assert.ErrorIs(t, err, errTest)
+	_, err = First(failAfter[string]())
+	assert.ErrorIs(t, err, errTest)
+}
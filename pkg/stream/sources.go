@@ -4,6 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"strings"
+
+	"github.com/cockroachdb/errors"
 )
 
 // From returns a stream from a given slice
@@ -36,19 +39,48 @@ func (l *sliceSource[V]) Next() (next V, err error) {
 	return next, nil
 }
 
+// ScannerOption configures the scanner used to split an input into a stream of strings
+type ScannerOption func(*bufio.Scanner)
+
+// WithBuffer sets the initial size of the buffer used to read each token, and the largest
+// token which can be read, as [bufio.Scanner.Buffer] does.
+//
+// Streams reading from an [io.Reader] default to a largest token of [bufio.MaxScanTokenSize].
+func WithBuffer(initial, max int) ScannerOption {
+	return func(scanner *bufio.Scanner) {
+		scanner.Buffer(make([]byte, 0, initial), max)
+	}
+}
+
+// WithWholeInputBuffer allows tokens as long as the whole input, for inputs which are already in memory
+func WithWholeInputBuffer(input []byte) ScannerOption {
+	return WithBuffer(0, max(len(input)+1, bufio.MaxScanTokenSize))
+}
+
 // LinesFrom returns a stream of lines from the given input.
+//
+// Leading and trailing whitespace around the input is ignored.
 func LinesFrom(input []byte) Stream[string] {
-	return scannerSource{
-		scanner: bufio.NewScanner(bytes.NewReader(bytes.TrimSpace(input))),
-	}
+	return LinesFromReader(bytes.NewReader(input), WithWholeInputBuffer(input))
+}
+
+// LinesFromReader returns a stream of lines read from the given reader as the stream is read,
+// so the whole input never needs to be held in memory.
+//
+// Like [LinesFrom], leading and trailing whitespace around the input is ignored.
+func LinesFromReader(input io.Reader, options ...ScannerOption) Stream[string] {
+	return newScannerSource(input, bufio.ScanLines, options)
 }
 
 // SplitBy returns a stream of strings split by the given byte.
 func SplitBy(input []byte, split byte) Stream[string] {
-	s := scannerSource{
-		scanner: bufio.NewScanner(bytes.NewReader(bytes.TrimSpace(input))),
-	}
-	s.scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return SplitByReader(bytes.NewReader(input), split, WithWholeInputBuffer(input))
+}
+
+// SplitByReader returns a stream of strings split by the given byte, read from
+// the given reader as the stream is read.
+func SplitByReader(input io.Reader, split byte, options ...ScannerOption) Stream[string] {
+	return newScannerSource(input, func(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		if atEOF && len(data) == 0 {
 			return 0, nil, nil
 		}
@@ -62,18 +94,31 @@ func SplitBy(input []byte, split byte) Stream[string] {
 		}
 		// Request more data.
 		return 0, nil, nil
-	})
-	return s
+	}, options)
 }
 
 type scannerSource struct {
 	scanner *bufio.Scanner
 }
 
+// newScannerSource returns a stream of the tokens split from the input, with the
+// whitespace around the input trimmed
+func newScannerSource(input io.Reader, split bufio.SplitFunc, options []ScannerOption) scannerSource {
+	scanner := bufio.NewScanner(&trimSpaceReader{input: input})
+	scanner.Split(split)
+	for _, option := range options {
+		option(scanner)
+	}
+
+	return scannerSource{scanner: scanner}
+}
+
 func (l scannerSource) Next() (string, error) {
 	if !l.scanner.Scan() {
 		err := l.scanner.Err()
-		if err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return "", errors.Wrap(err, "token is larger than the scanner buffer, use stream.WithBuffer to allow larger tokens")
+		} else if err != nil {
 			return "", err
 		} else {
 			return "", io.EOF
@@ -82,3 +127,60 @@ func (l scannerSource) Next() (string, error) {
 
 	return l.scanner.Text(), nil
 }
+
+// trimSpaceReader removes the leading and trailing whitespace from the input as it is read, in the
+// same way [bytes.TrimSpace] does for ASCII whitespace, only holding back runs of whitespace which
+// could be at the end of the input.
+type trimSpaceReader struct {
+	input   io.Reader
+	started bool   // Set once anything other than whitespace has been read
+	held    []byte // Whitespace which is only output if something other than whitespace follows
+	pending []byte // Read but not yet returned
+	buf     []byte
+	err     error // The error from the input, returned once everything pending has been
+}
+
+func (t *trimSpaceReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(t.pending) == 0 {
+		if t.err != nil {
+			return 0, t.err
+		}
+
+		if len(t.buf) < len(p) {
+			t.buf = make([]byte, len(p))
+		}
+
+		n, err := t.input.Read(t.buf[:len(p)])
+		t.err = err
+		chunk := t.buf[:n]
+
+		if !t.started {
+			chunk = bytes.TrimLeft(chunk, asciiSpace)
+			t.started = len(chunk) > 0
+		}
+
+		last := len(chunk) - 1
+		for last >= 0 && strings.IndexByte(asciiSpace, chunk[last]) >= 0 {
+			last--
+		}
+
+		if last < 0 {
+			t.held = append(t.held, chunk...)
+			continue
+		}
+
+		t.pending = append(append(t.pending[:0], t.held...), chunk[:last+1]...)
+		t.held = append(t.held[:0], chunk[last+1:]...)
+	}
+
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+// asciiSpace is the whitespace trimmed from around the input
+const asciiSpace = " \t\n\v\f\r"
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinesFromReader(t *testing.T) {
+	for _, input := range []string{
+		"",
+		" \n\t ",
+		"one",
+		"one\ntwo\n",
+		"\n\n  one\n\ntwo  \n\n three \n\n\n",
+		"one\r\ntwo\r\n",
+	} {
+		want, err := Collect(LinesFrom([]byte(input)))
+		require.NoError(t, err)
+
+		// Read a byte at a time so whitespace is split across reads
+		got, err := Collect(LinesFromReader(iotest.OneByteReader(strings.NewReader(input))))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "lines from reader should match LinesFrom for %q", input)
+	}
+}
+
+func TestLinesFromReader_LongLines(t *testing.T) {
+	long := strings.Repeat("x", 2*bufio.MaxScanTokenSize)
+	input := "short\n" + long + "\nshort"
+	want := []string{"short", long, "short"}
+
+	lines, err := Collect(LinesFrom([]byte(input)))
+	require.NoError(t, err)
+	assert.Equal(t, want, lines, "lines from a slice can be as long as the input")
+
+	_, err = Collect(LinesFromReader(strings.NewReader(input)))
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+
+	lines, err = Collect(LinesFromReader(strings.NewReader(input), WithBuffer(1024, 4*bufio.MaxScanTokenSize)))
+	require.NoError(t, err)
+	assert.Equal(t, want, lines)
+}
+
+func TestSplitByReader(t *testing.T) {
+	input := []byte(" rn=1,cm-,qp=3 \n")
+
+	want, err := Collect(SplitBy(input, ','))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rn=1", "cm-", "qp=3"}, want)
+
+	got, err := Collect(SplitByReader(iotest.HalfReader(bytes.NewReader(input)), ','))
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}